@@ -8,6 +8,12 @@ const (
 	grpcProtocol ProtocolType = "grpc"
 )
 
+// Supported protocols
+const (
+	ProtocolHTTP = httpProtocol
+	ProtocolGRPC = grpcProtocol
+)
+
 // A Code is a status code defined
 type Code uint32
 
@@ -63,3 +69,25 @@ const (
 	gRPCDataLoss           Code = 15
 	gRPCUnauthenticated    Code = 16
 )
+
+// Коды gRPC для адаптеров протоколов, сопоставляющих свои коды с gRPC
+// gRPC codes for protocol adapters mapping their own codes to gRPC
+const (
+	GRPCOk                 = gRPCOk
+	GRPCCanceled           = gRPCCanceled
+	GRPCUnknown            = gRPCUnknown
+	GRPCInvalidArgument    = gRPCInvalidArgument
+	GRPCDeadlineExceeded   = gRPCDeadlineExceeded
+	GRPCNotFound           = gRPCNotFound
+	GRPCAlreadyExists      = gRPCAlreadyExists
+	GRPCPermissionDenied   = gRPCPermissionDenied
+	GRPCResourceExhausted  = gRPCResourceExhausted
+	GRPCFailedPrecondition = gRPCFailedPrecondition
+	GRPCAborted            = gRPCAborted
+	GRPCOutOfRange         = gRPCOutOfRange
+	GRPCUnimplemented      = gRPCUnimplemented
+	GRPCInternal           = gRPCInternal
+	GRPCUnavailable        = gRPCUnavailable
+	GRPCDataLoss           = gRPCDataLoss
+	GRPCUnauthenticated    = gRPCUnauthenticated
+)
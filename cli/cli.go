@@ -0,0 +1,113 @@
+// Package cli преобразует ошибки в коды завершения процесса BSD sysexits
+// Package cli converts errors into BSD sysexits process exit codes
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	errs "github.com/eserg-key/errors"
+)
+
+// Exit codes from BSD sysexits.h
+const (
+	ExitOK          = 0
+	ExitUsage       = 64
+	ExitNoInput     = 66
+	ExitUnavailable = 69
+	ExitSoftware    = 70
+	ExitCantCreate  = 73
+	ExitTempFail    = 75
+	ExitNoPerm      = 77
+)
+
+// ExitCode возвращает код завершения процесса для ошибки
+// ExitCode returns the process exit code for the error
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	switch errs.StatusGRPC(err) {
+	case errs.GRPCInvalidArgument, errs.GRPCFailedPrecondition, errs.GRPCOutOfRange:
+		return ExitUsage
+	case errs.GRPCNotFound:
+		return ExitNoInput
+	case errs.GRPCUnavailable:
+		return ExitUnavailable
+	case errs.GRPCAlreadyExists:
+		return ExitCantCreate
+	case errs.GRPCPermissionDenied, errs.GRPCUnauthenticated:
+		return ExitNoPerm
+	case errs.GRPCDeadlineExceeded:
+		return ExitTempFail
+	}
+
+	if errs.IsRetryable(err) {
+		return ExitTempFail
+	}
+	return ExitSoftware
+}
+
+// Exiter печатает ошибку и завершает процесс с кодом ExitCode.
+// Нулевое значение пишет в os.Stderr и вызывает os.Exit
+// Exiter prints the error and terminates the process with the ExitCode code.
+// A zero value writes to os.Stderr and calls os.Exit
+type Exiter struct {
+	// Stderr - поток для вывода ошибки
+	// Stderr - stream the error is printed to
+	Stderr io.Writer
+
+	// ExitFunc завершает процесс. Подменяется в тестах
+	// ExitFunc terminates the process. It is replaced in tests
+	ExitFunc func(code int)
+
+	// Verbose включает вывод полного текста, причины и стека ошибки
+	// Verbose enables printing of the full text, the reason and the stack of the error
+	Verbose bool
+}
+
+// Exit печатает ошибку и завершает процесс. Для nil процесс завершается с кодом 0
+// без вывода
+// Exit prints the error and terminates the process. For nil the process exits
+// with code 0 and prints nothing
+func (e *Exiter) Exit(err error) {
+	if err != nil {
+		e.print(err)
+	}
+
+	exit := e.ExitFunc
+	if exit == nil {
+		exit = os.Exit
+	}
+	exit(ExitCode(err))
+}
+
+// print writes the error to Stderr
+func (e *Exiter) print(err error) {
+	w := e.Stderr
+	if w == nil {
+		w = os.Stderr
+	}
+
+	if !e.Verbose {
+		fmt.Fprintf(w, "error: %s\n", errs.PublicMessage(err))
+		return
+	}
+
+	fmt.Fprintf(w, "error: %s\n", err.Error())
+	fmt.Fprintf(w, "code: %s\n", errs.CodeName(err))
+	if reason := errs.Reason(err); reason != "" {
+		fmt.Fprintf(w, "reason: %s\n", reason)
+	}
+	if stack := errs.Stack(err); len(stack) > 0 {
+		fmt.Fprintf(w, "%s", stack)
+	}
+}
+
+// Exit печатает ошибку в os.Stderr и завершает процесс с кодом ExitCode
+// Exit prints the error to os.Stderr and terminates the process with the ExitCode code
+func Exit(err error) {
+	(&Exiter{}).Exit(err)
+}
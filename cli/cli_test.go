@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	errs "github.com/eserg-key/errors"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"Nil Error", nil, ExitOK},
+		{"Invalid Argument", errs.BadRequestHTTP("Bad flag"), ExitUsage},
+		{"Not Found", errs.NotFoundGRPC("No such file"), ExitNoInput},
+		{"Unavailable", errs.ServiceUnavailableHTTP("Down"), ExitUnavailable},
+		{"Already Exists", errs.AlreadyExistsGRPC("Exists"), ExitCantCreate},
+		{"Permission Denied", errs.ForbiddenHTTP("Denied"), ExitNoPerm},
+		{"Retryable", errs.AbortedGRPC("Conflict"), ExitTempFail},
+		{"Retry Hint", errs.New(errs.ProtocolGRPC, 13, "Busy", errs.RetryAfterOption(time.Second)), ExitTempFail},
+		{"Deadline Exceeded", context.DeadlineExceeded, ExitTempFail},
+		{"Internal", errs.InternalGRPC("Bug"), ExitSoftware},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := ExitCode(tt.err); code != tt.expected {
+				t.Errorf("Expected exit code %d, got %d", tt.expected, code)
+			}
+		})
+	}
+}
+
+func TestExiter(t *testing.T) {
+	var stderr strings.Builder
+	var code int
+	exiter := &Exiter{Stderr: &stderr, ExitFunc: func(c int) { code = c }}
+
+	err := errs.New(errs.ProtocolGRPC, 5, "open /tmp/x: no such file",
+		errs.ReasonOption("INPUT_MISSING"),
+		errs.PublicMessageOption("Input file not found"),
+	)
+
+	exiter.Exit(err)
+	if code != ExitNoInput {
+		t.Errorf("Expected exit code %d, got %d", ExitNoInput, code)
+	}
+	if stderr.String() != "error: Input file not found\n" {
+		t.Errorf("Unexpected output %q", stderr.String())
+	}
+
+	stderr.Reset()
+	exiter.Verbose = true
+	exiter.Exit(err)
+	expected := "error: open /tmp/x: no such file\ncode: NOT_FOUND\nreason: INPUT_MISSING\n"
+	if stderr.String() != expected {
+		t.Errorf("Expected output %q, got %q", expected, stderr.String())
+	}
+
+	stderr.Reset()
+	exiter.Exit(nil)
+	if code != ExitOK || stderr.Len() != 0 {
+		t.Errorf("Expected silent exit with code 0, got %d and %q", code, stderr.String())
+	}
+}
@@ -0,0 +1,131 @@
+// Package connect кодирует ошибки в JSON-формат ошибок протокола Connect и обратно
+// Package connect encodes errors into the Connect protocol JSON error format and back
+package connect
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	errs "github.com/eserg-key/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// codes lists Connect codes and their HTTP statuses indexed by gRPC code
+var codes = [...]struct {
+	name   string
+	status int
+}{
+	errs.GRPCOk:                 {"", 200},
+	errs.GRPCCanceled:           {"canceled", 499},
+	errs.GRPCUnknown:            {"unknown", 500},
+	errs.GRPCInvalidArgument:    {"invalid_argument", 400},
+	errs.GRPCDeadlineExceeded:   {"deadline_exceeded", 504},
+	errs.GRPCNotFound:           {"not_found", 404},
+	errs.GRPCAlreadyExists:      {"already_exists", 409},
+	errs.GRPCPermissionDenied:   {"permission_denied", 403},
+	errs.GRPCResourceExhausted:  {"resource_exhausted", 429},
+	errs.GRPCFailedPrecondition: {"failed_precondition", 400},
+	errs.GRPCAborted:            {"aborted", 409},
+	errs.GRPCOutOfRange:         {"out_of_range", 400},
+	errs.GRPCUnimplemented:      {"unimplemented", 501},
+	errs.GRPCInternal:           {"internal", 500},
+	errs.GRPCUnavailable:        {"unavailable", 503},
+	errs.GRPCDataLoss:           {"data_loss", 500},
+	errs.GRPCUnauthenticated:    {"unauthenticated", 401},
+}
+
+// Error - ошибка в JSON-формате Connect
+// Error - error in the Connect JSON format
+type Error struct {
+	Code    string   `json:"code"`
+	Message string   `json:"message,omitempty"`
+	Details []Detail `json:"details,omitempty"`
+}
+
+// Detail - деталь ошибки Connect: имя типа protobuf и сообщение в base64 без дополнения
+// Detail - Connect error detail: protobuf type name and the message in unpadded base64
+type Detail struct {
+	Type  string          `json:"type"`
+	Value string          `json:"value"`
+	Debug json.RawMessage `json:"debug,omitempty"`
+}
+
+// Encode преобразует ошибку в ошибку Connect и возвращает HTTP-статус ответа
+// по спецификации Connect
+// Encode converts the error into a Connect error and returns the HTTP status
+// of the response as specified by Connect
+func Encode(err error) (*Error, int) {
+	if err == nil {
+		return nil, 200
+	}
+
+	code := errs.StatusGRPC(err)
+	if int(code) >= len(codes) || code == errs.GRPCOk {
+		code = errs.GRPCUnknown
+	}
+
+	e := &Error{Code: codes[code].name, Message: errs.PublicMessage(err)}
+	for _, detail := range errs.Details(err) {
+		data, marshalErr := proto.Marshal(detail)
+		if marshalErr != nil {
+			continue
+		}
+		e.Details = append(e.Details, Detail{
+			Type:  string(detail.ProtoReflect().Descriptor().FullName()),
+			Value: base64.RawStdEncoding.EncodeToString(data),
+		})
+	}
+	return e, codes[code].status
+}
+
+// Decode преобразует ошибку Connect в ошибку с gRPC кодом. Детали неизвестных
+// типов сохраняются как google.protobuf.Any
+// Decode converts a Connect error into an error with a gRPC code. Details of
+// unknown types are kept as google.protobuf.Any
+func Decode(e *Error) error {
+	if e == nil {
+		return nil
+	}
+
+	var details []proto.Message
+	for _, d := range e.Details {
+		if detail, ok := decodeDetail(d); ok {
+			details = append(details, detail)
+		}
+	}
+	return errs.New(errs.ProtocolGRPC, codeFromName(e.Code), e.Message, errs.DetailsOption(details...))
+}
+
+// codeFromName returns the gRPC code of the Connect code name
+func codeFromName(name string) errs.Code {
+	for code, c := range codes {
+		if code > int(errs.GRPCOk) && c.name == name {
+			return errs.Code(code)
+		}
+	}
+	return errs.GRPCUnknown
+}
+
+// decodeDetail decodes the detail using the global protobuf type registry
+func decodeDetail(d Detail) (proto.Message, bool) {
+	data, err := base64.RawStdEncoding.DecodeString(d.Value)
+	if err != nil {
+		if data, err = base64.StdEncoding.DecodeString(d.Value); err != nil {
+			return nil, false
+		}
+	}
+
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(d.Type))
+	if err != nil {
+		return &anypb.Any{TypeUrl: "type.googleapis.com/" + d.Type, Value: data}, true
+	}
+
+	detail := mt.New().Interface()
+	if proto.Unmarshal(data, detail) != nil {
+		return nil, false
+	}
+	return detail, true
+}
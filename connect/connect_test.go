@@ -0,0 +1,72 @@
+package connect
+
+import (
+	"encoding/json"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedCode   string
+		expectedStatus int
+	}{
+		{"Not Found", errs.NotFoundHTTP("Not found"), "not_found", 404},
+		{"Canceled", errs.CanceledGRPC("Canceled"), "canceled", 499},
+		{"Failed Precondition", errs.FailedPreconditionGRPC("Precondition"), "failed_precondition", 400},
+		{"Unavailable", errs.UnavailableGRPC("Unavailable"), "unavailable", 503},
+		{"Unauthenticated", errs.UnauthorizedHTTP("Login"), "unauthenticated", 401},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, status := Encode(tt.err)
+			if e.Code != tt.expectedCode || status != tt.expectedStatus {
+				t.Errorf("Expected %s/%d, got %s/%d", tt.expectedCode, tt.expectedStatus, e.Code, status)
+			}
+		})
+	}
+
+	if e, status := Encode(nil); e != nil || status != 200 {
+		t.Error("Expected nil and 200 for nil error")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := errs.New(errs.ProtocolGRPC, 9, "Email not verified",
+		errs.DetailsOption(&errdetails.ErrorInfo{Reason: "EMAIL_UNVERIFIED", Domain: "example.com"}))
+
+	e, _ := Encode(original)
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	var decodedJSON Error
+	if err := json.Unmarshal(data, &decodedJSON); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	if decodedJSON.Details[0].Type != "google.rpc.ErrorInfo" {
+		t.Errorf("Unexpected detail type %s", decodedJSON.Details[0].Type)
+	}
+
+	decoded := Decode(&decodedJSON)
+	if errs.StatusGRPC(decoded) != 9 || decoded.Error() != "Email not verified" {
+		t.Errorf("Unexpected decoded error %v", decoded)
+	}
+
+	details := errs.Details(decoded)
+	if info, ok := details[0].(*errdetails.ErrorInfo); !ok || info.GetReason() != "EMAIL_UNVERIFIED" {
+		t.Errorf("Unexpected detail %v", details[0])
+	}
+}
+
+func TestDecodeUnknownCode(t *testing.T) {
+	if errs.StatusGRPC(Decode(&Error{Code: "teapot"})) != 2 {
+		t.Error("Expected unknown Connect code to decode as Unknown")
+	}
+}
@@ -0,0 +1,121 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+// Metadata keys filled from the request context
+const (
+	RequestIDKey = "request_id"
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+	TenantKey    = "tenant"
+)
+
+// ContextExtractor возвращает метаданные запроса, которые нужно добавить к ошибке
+// ContextExtractor returns request metadata to be added to the error
+type ContextExtractor func(ctx context.Context) map[string]string
+
+// contextKey is the type of context keys of the package
+type contextKey int
+
+const (
+	requestIDContextKey contextKey = iota
+	tenantContextKey
+)
+
+var (
+	extractorsMu sync.RWMutex
+	extractors   = []ContextExtractor{requestExtractor}
+)
+
+// RegisterContextExtractor добавляет извлекатель метаданных запроса, используемый
+// WithContext и ContextOption. По умолчанию извлекаются идентификатор запроса и арендатор,
+// сохраненные через ContextWithRequestID и ContextWithTenant
+// RegisterContextExtractor adds an extractor of request metadata used by WithContext
+// and ContextOption. By default the request ID and the tenant stored via
+// ContextWithRequestID and ContextWithTenant are extracted
+func RegisterContextExtractor(extractor ContextExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+	extractors = append(extractors, extractor)
+}
+
+// ContextWithRequestID сохраняет идентификатор запроса в контексте
+// ContextWithRequestID stores the request ID in the context
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// ContextWithTenant сохраняет арендатора в контексте
+// ContextWithTenant stores the tenant in the context
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey, tenant)
+}
+
+// WithContext возвращает копию ошибки с метаданными запроса из контекста.
+// Метаданные, уже заданные в ошибке, не перезаписываются. Ошибки других типов,
+// в том числе обертки над *Error, становятся причиной новой ошибки с их текстом,
+// протоколом и кодом, как в Wrap
+// WithContext returns a copy of the error with request metadata from the context.
+// Metadata already set on the error is not overwritten. Errors of other types,
+// including wrappers of *Error, become the cause of a new error with their text,
+// protocol and code, as in Wrap
+func WithContext(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	cp := annotate(err)
+	cp.metadata = mergeMetadata(extractContext(ctx), cp.metadata)
+	return cp
+}
+
+// ContextOption добавляет метаданные запроса из контекста
+// ContextOption adds request metadata from the context
+func ContextOption(ctx context.Context) Option {
+	return func(e *Error) {
+		e.metadata = mergeMetadata(extractContext(ctx), e.metadata)
+	}
+}
+
+// extractContext collects metadata from all registered extractors
+func extractContext(ctx context.Context) map[string]string {
+	extractorsMu.RLock()
+	defer extractorsMu.RUnlock()
+
+	var metadata map[string]string
+	for _, extractor := range extractors {
+		metadata = mergeMetadata(metadata, extractor(ctx))
+	}
+	return metadata
+}
+
+// mergeMetadata returns a new map with the values of override applied over base
+func mergeMetadata(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		metadata[k] = v
+	}
+	for k, v := range override {
+		metadata[k] = v
+	}
+	return metadata
+}
+
+// requestExtractor extracts the request ID and the tenant stored by the package
+func requestExtractor(ctx context.Context) map[string]string {
+	metadata := make(map[string]string, 2)
+	if requestID, ok := ctx.Value(requestIDContextKey).(string); ok && requestID != "" {
+		metadata[RequestIDKey] = requestID
+	}
+	if tenant, ok := ctx.Value(tenantContextKey).(string); ok && tenant != "" {
+		metadata[TenantKey] = tenant
+	}
+	return metadata
+}
@@ -101,3 +101,45 @@ func statusGRPCToHTTP(grpcCode Code) Code {
 		return hTTPInternalServerError
 	}
 }
+
+// codeNameGRPC returns the canonical name of the gRPC code
+func codeNameGRPC(grpcCode Code) string {
+	switch grpcCode {
+	case gRPCOk:
+		return "OK"
+	case gRPCCanceled:
+		return "CANCELLED"
+	case gRPCUnknown:
+		return "UNKNOWN"
+	case gRPCInvalidArgument:
+		return "INVALID_ARGUMENT"
+	case gRPCDeadlineExceeded:
+		return "DEADLINE_EXCEEDED"
+	case gRPCNotFound:
+		return "NOT_FOUND"
+	case gRPCAlreadyExists:
+		return "ALREADY_EXISTS"
+	case gRPCPermissionDenied:
+		return "PERMISSION_DENIED"
+	case gRPCResourceExhausted:
+		return "RESOURCE_EXHAUSTED"
+	case gRPCFailedPrecondition:
+		return "FAILED_PRECONDITION"
+	case gRPCAborted:
+		return "ABORTED"
+	case gRPCOutOfRange:
+		return "OUT_OF_RANGE"
+	case gRPCUnimplemented:
+		return "UNIMPLEMENTED"
+	case gRPCInternal:
+		return "INTERNAL"
+	case gRPCUnavailable:
+		return "UNAVAILABLE"
+	case gRPCDataLoss:
+		return "DATA_LOSS"
+	case gRPCUnauthenticated:
+		return "UNAUTHENTICATED"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -2,8 +2,12 @@ package errors
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/go-multierror"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 	"strings"
+	"time"
 )
 
 // Error универсальная структура для ошибок с HTTP и gRPC статусами
@@ -12,6 +16,17 @@ type Error struct {
 	Message      string
 	code         Code
 	typeProtocol ProtocolType
+	reason       string
+	domain       string
+	severity     Severity
+	metadata     map[string]string
+	details      []proto.Message
+	retryAfter   time.Duration
+	public       string
+	id           string
+	origin       string
+	stack        []byte
+	cause        error
 }
 
 // Error возвращает текстовое представление ошибки
@@ -20,6 +35,12 @@ func (e *Error) Error() string {
 	return e.Message
 }
 
+// Unwrap возвращает причину ошибки
+// Unwrap returns the cause of the error
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
 // StatusHTTP возвращает HTTP-статус ошибки
 // StatusHTTP returns the HTTP status of the error
 func StatusHTTP(err error) int {
@@ -27,12 +48,15 @@ func StatusHTTP(err error) int {
 		return int(hTTPOk)
 	}
 
-	var er *Error
-	if errors.As(err, &er) {
-		if err.(*Error).typeProtocol == httpProtocol {
-			return int(err.(*Error).code)
+	er, me := resolve(err)
+	if me != nil {
+		return StatusHTTP(mostSevere(me.Errors))
+	}
+	if er != nil {
+		if er.typeProtocol == httpProtocol {
+			return int(er.code)
 		}
-		return int(statusGRPCToHTTP(err.(*Error).code))
+		return int(statusGRPCToHTTP(er.code))
 	}
 
 	// Handling other types of errors
@@ -54,12 +78,15 @@ func StatusGRPC(err error) Code {
 		return gRPCOk
 	}
 
-	var er *Error
-	if errors.As(err, &er) {
-		if err.(*Error).typeProtocol == grpcProtocol {
-			return err.(*Error).code
+	er, me := resolve(err)
+	if me != nil {
+		return StatusGRPC(mostSevere(me.Errors))
+	}
+	if er != nil {
+		if er.typeProtocol == grpcProtocol {
+			return er.code
 		}
-		return statusHTTPToGRPC(err.(*Error).code)
+		return statusHTTPToGRPC(er.code)
 	}
 
 	// Handling other types of errors
@@ -74,21 +101,327 @@ func StatusGRPC(err error) Code {
 	return gRPCUnknown
 }
 
-// Wrap обертывает ошибку с дополнительным сообщением, сохраняя код исходной ошибки
-// Wrap wraps an error with an additional message, preserving the original error's code
-func Wrap(err error, message string) error {
+// Protocol возвращает протокол, к которому относится код ошибки.
+// Для ошибок других типов возвращается пустая строка
+// Protocol returns the protocol the error code belongs to.
+// For errors of other types it returns an empty string
+func Protocol(err error) ProtocolType {
+	var er *Error
+	if errors.As(err, &er) {
+		return er.typeProtocol
+	}
+	return ""
+}
+
+// CodeName возвращает каноническое имя gRPC кода ошибки, например NOT_FOUND
+// CodeName returns the canonical name of the error's gRPC code, e.g. NOT_FOUND
+func CodeName(err error) string {
+	return codeNameGRPC(StatusGRPC(err))
+}
+
+// IsRetryable сообщает, можно ли повторить операцию, завершившуюся ошибкой
+// IsRetryable reports whether the operation that failed with err can be retried
+func IsRetryable(err error) bool {
+	if _, ok := RetryAfter(err); ok {
+		return true
+	}
+
+	switch StatusGRPC(err) {
+	case gRPCUnavailable, gRPCAborted, gRPCResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Reason возвращает машиночитаемую причину ошибки
+// Reason returns the machine-readable reason of the error
+func Reason(err error) string {
+	var er *Error
+	if errors.As(err, &er) {
+		return er.reason
+	}
+	return ""
+}
+
+// WithReason возвращает копию ошибки с машиночитаемой причиной, например USER_NOT_FOUND.
+// Ошибки других типов, в том числе обертки над *Error, становятся причиной новой ошибки
+// с их текстом, протоколом и кодом, как в Wrap
+// WithReason returns a copy of the error with a machine-readable reason, e.g. USER_NOT_FOUND.
+// Errors of other types, including wrappers of *Error, become the cause of a new error
+// with their text, protocol and code, as in Wrap
+func WithReason(err error, reason string) error {
+	if err == nil {
+		return nil
+	}
+
+	cp := annotate(err)
+	cp.reason = reason
+	return cp
+}
+
+// Stack возвращает стек вызовов, сохранённый в ошибке
+// Stack returns the call stack stored in the error
+func Stack(err error) []byte {
+	var er *Error
+	if errors.As(err, &er) {
+		return er.stack
+	}
+	return nil
+}
+
+// resolve возвращает ближайшую к началу цепочки ошибку *Error или непустую
+// *multierror.Error, так что код внешней обертки важнее агрегированных ошибок внутри
+// resolve returns the *Error or the non-empty *multierror.Error closest to the start
+// of the chain, so the code of an outer wrapper wins over errors aggregated inside
+func resolve(err error) (*Error, *multierror.Error) {
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		switch e := cur.(type) {
+		case *Error:
+			return e, nil
+		case *multierror.Error:
+			if len(e.Errors) > 0 {
+				return nil, e
+			}
+		}
+	}
+
+	// Errors with several causes are not followed by errors.Unwrap
 	var er *Error
+	if errors.As(err, &er) {
+		return er, nil
+	}
+	return nil, nil
+}
+
+// mostSevere возвращает самую серьезную ошибку: с наибольшим классом HTTP-статуса,
+// затем ту, которую нельзя повторить, затем первую по порядку
+// mostSevere returns the most severe error: the one with the highest HTTP status class,
+// then the one that cannot be retried, then the first one in order
+func mostSevere(errs []error) error {
+	var result error
+	for _, err := range errs {
+		if result == nil || severityRank(err) > severityRank(result) {
+			result = err
+		}
+	}
+	return result
+}
+
+// severityRank returns the rank of the error used by mostSevere
+func severityRank(err error) int {
+	rank := StatusHTTP(err) / 100 * 2
+	if !IsRetryable(err) {
+		rank++
+	}
+	return rank
+}
+
+// Wrap обертывает ошибку с дополнительным сообщением, сохраняя код исходной ошибки.
+// Агрегированные ошибки получают протокол и код самой серьезной из них, ошибки других
+// типов - gRPC код, полученный через StatusGRPC. Для nil возвращается nil
+// Wrap wraps an error with an additional message, preserving the original error's code.
+// Aggregated errors get the protocol and the code of the most severe one, errors of other
+// types get the gRPC code obtained via StatusGRPC. For nil it returns nil
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+
 	var builder strings.Builder
 	builder.WriteString(message + ":")
 	builder.WriteString(err.Error())
 
-	if errors.As(err, &er) {
-		return &Error{
-			Message:      builder.String(),
-			code:         err.(*Error).code,
-			typeProtocol: err.(*Error).typeProtocol,
+	return derive(err, builder.String())
+}
+
+// derive возвращает копию ближайшей *Error в цепочке с сообщением message и причиной err,
+// сохраняя её протокол, код, причину и остальные поля. Если такой ошибки нет,
+// создается новая ошибка через wrapStatus
+// derive returns a copy of the closest *Error in the chain with the message and the cause err,
+// keeping its protocol, code, reason and the other fields. Without such an error
+// a new one is created via wrapStatus
+func derive(err error, message string) *Error {
+	if er, me := resolve(err); er != nil && me == nil {
+		cp := *er
+		cp.Message = message
+		cp.cause = err
+		return &cp
+	}
+	return wrapStatus(err, message)
+}
+
+// annotate returns a copy of the error to be changed by WithReason and similar functions:
+// a copy of *Error itself or, for other types, a new error derived from it with its text
+func annotate(err error) *Error {
+	if er, ok := err.(*Error); ok {
+		cp := *er
+		return &cp
+	}
+	return derive(err, err.Error())
+}
+
+// wrapStatus создает новую ошибку с причиной err, протоколом и кодом, с которыми
+// сообщается err: ближайшей *Error в цепочке, самой серьезной из агрегированных
+// ошибок или gRPC кодом, полученным через StatusGRPC
+// wrapStatus creates a new error with the cause err and the protocol and code err
+// is reported with: those of the closest *Error in the chain, of the most severe
+// aggregated error or the gRPC code obtained via StatusGRPC
+func wrapStatus(err error, message string) *Error {
+	protocol, code := statusOf(err)
+	return (&Error{
+		Message:      message,
+		code:         code,
+		typeProtocol: protocol,
+		cause:        err,
+	}).stamp()
+}
+
+// statusOf returns the protocol and the code the error is reported with
+func statusOf(err error) (ProtocolType, Code) {
+	er, me := resolve(err)
+	if me != nil {
+		return statusOf(mostSevere(me.Errors))
+	}
+	if er != nil {
+		return er.typeProtocol, er.code
+	}
+	return grpcProtocol, StatusGRPC(err)
+}
+
+// Wrapf обертывает ошибку с форматированным сообщением, сохраняя код исходной ошибки.
+// Как и у Wrap, текст результата - "сообщение:текст err", например
+// Wrapf(NotFoundHTTP("nf"), "ctx %w", io.EOF) дает "ctx EOF:nf". Ошибки, переданные
+// через %w, становятся причинами результата наряду с err, и Unwrap возвращает их
+// вместе; MarshalJSON и ToProto передают такие причины в поле causes
+// Wrapf wraps an error with a formatted message, preserving the original error's code.
+// As with Wrap, the text of the result is "message:err text", e.g.
+// Wrapf(NotFoundHTTP("nf"), "ctx %w", io.EOF) gives "ctx EOF:nf". Errors passed
+// via %w become causes of the result alongside err and are returned by Unwrap
+// together; MarshalJSON and ToProto transport such causes in the causes field
+func Wrapf(err error, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	message, cause := formatCause(format, args...)
+	if cause != nil {
+		return Wrap(causes{err, cause}, message)
+	}
+	return Wrap(err, message)
+}
+
+// WrapHTTP обертывает ошибку с дополнительным сообщением и новым HTTP-статусом,
+// например превращая NotFound репозитория в 422 на уровне API. Для nil возвращается nil.
+// Для недопустимого кода возвращается ошибка, удовлетворяющая errors.Is(err, ErrInvalidCode)
+// и сохраняющая исходную ошибку в цепочке
+// WrapHTTP wraps an error with an additional message and a new HTTP status,
+// e.g. turning a repository NotFound into 422 at the API layer. For nil it returns nil.
+// For an invalid code an error satisfying errors.Is(err, ErrInvalidCode) is returned,
+// keeping the original error in the chain
+func WrapHTTP(err error, code Code, message string) error {
+	return wrapWithCode(err, httpProtocol, code, message)
+}
+
+// WrapGRPC обертывает ошибку с дополнительным сообщением и новым gRPC кодом.
+// Для nil возвращается nil. Недопустимый код обрабатывается как в WrapHTTP
+// WrapGRPC wraps an error with an additional message and a new gRPC code.
+// For nil it returns nil. An invalid code is handled as in WrapHTTP
+func WrapGRPC(err error, code Code, message string) error {
+	return wrapWithCode(err, grpcProtocol, code, message)
+}
+
+// wrapWithCode wraps the error and replaces the code of the result
+func wrapWithCode(err error, protocol ProtocolType, code Code, message string) error {
+	if err == nil {
+		return nil
+	}
+	if !validCode(protocol, code) {
+		return fmt.Errorf("%w: %d for %q: %w", ErrInvalidCode, code, protocol, err)
+	}
+
+	wrapped := Wrap(err, message).(*Error)
+	wrapped.code = code
+	wrapped.typeProtocol = protocol
+	return wrapped
+}
+
+// causes объединяет несколько причин ошибки. Текст берется из первой причины
+// causes joins several causes of an error. The text is taken from the first cause
+type causes []error
+
+// Error returns the text of the first cause
+func (c causes) Error() string {
+	return c[0].Error()
+}
+
+// Unwrap returns all causes
+func (c causes) Unwrap() []error {
+	return c
+}
+
+// plainError - причина другого типа, восстановленная из JSON или protobuf. Сохраняет
+// только текст и причины, поэтому не влияет на коды и не находится через errors.As(*Error)
+// plainError is a cause of another type restored from JSON or protobuf. It keeps
+// only the text and the causes, so it affects no codes and is not found by errors.As(*Error)
+type plainError struct {
+	message string
+	cause   error
+}
+
+// Error returns the text of the original error
+func (e *plainError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the causes of the original error
+func (e *plainError) Unwrap() error {
+	return e.cause
+}
+
+// unwrapAll возвращает причины ошибки. Элементы causes возвращаются напрямую,
+// чтобы при передаче ошибки они не скрывались за текстом первой причины
+// unwrapAll returns the causes of the error. Members of causes are returned directly,
+// so they are not hidden behind the text of the first cause during transport
+func unwrapAll(err error) []error {
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		return u.Unwrap()
+	case interface{ Unwrap() error }:
+		cause := u.Unwrap()
+		if c, ok := cause.(causes); ok {
+			return c
+		}
+		if cause != nil {
+			return []error{cause}
 		}
 	}
+	return nil
+}
+
+// joinCauses returns the single cause or joins several causes
+func joinCauses(list []error) error {
+	switch len(list) {
+	case 0:
+		return nil
+	case 1:
+		return list[0]
+	default:
+		return causes(list)
+	}
+}
+
+// formatCause форматирует сообщение и возвращает ошибку, переданную через %w
+// formatCause formats the message and returns the error passed via %w
+func formatCause(format string, args ...any) (string, error) {
+	formatted := fmt.Errorf(format, args...)
 
-	return &Error{Message: builder.String()}
+	switch formatted.(type) {
+	case interface{ Unwrap() error }:
+		return formatted.Error(), errors.Unwrap(formatted)
+	case interface{ Unwrap() []error }:
+		return formatted.Error(), formatted
+	default:
+		return formatted.Error(), nil
+	}
 }
@@ -2,7 +2,18 @@ package errors
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/eserg-key/errors/errorspb"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"io"
+	"log/slog"
+	"regexp"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewHTTPError(t *testing.T) {
@@ -163,3 +174,929 @@ func TestContextErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"HTTP Not Found", NotFoundHTTP("User not found")},
+		{"gRPC Unavailable", UnavailableGRPC("Backend unavailable")},
+		{"Wrapped HTTP", Wrap(ConflictHTTP("Duplicate"), "Create failed")},
+		{"With Reason", WithReason(NotFoundGRPC("Missing"), "USER_NOT_FOUND")},
+		{"With Domain", New(ProtocolGRPC, 6, "Duplicate", DomainOption("users.example.com"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.err)
+			if err != nil {
+				t.Fatalf("Unexpected marshal error: %v", err)
+			}
+
+			decoded := &Error{}
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("Unexpected unmarshal error: %v", err)
+			}
+
+			if decoded.Error() != tt.err.Error() {
+				t.Errorf("Expected message '%s', got '%s'", tt.err.Error(), decoded.Error())
+			}
+			if StatusHTTP(decoded) != StatusHTTP(tt.err) {
+				t.Errorf("Expected HTTP status %d, got %d", StatusHTTP(tt.err), StatusHTTP(decoded))
+			}
+			if StatusGRPC(decoded) != StatusGRPC(tt.err) {
+				t.Errorf("Expected gRPC status %d, got %d", StatusGRPC(tt.err), StatusGRPC(decoded))
+			}
+			if Reason(decoded) != Reason(tt.err) {
+				t.Errorf("Expected reason '%s', got '%s'", Reason(tt.err), Reason(decoded))
+			}
+			if Domain(decoded) != Domain(tt.err) {
+				t.Errorf("Expected domain '%s', got '%s'", Domain(tt.err), Domain(decoded))
+			}
+		})
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	SetInstanceIDGenerator(func() string { return "01ARZ3NDEKTSV4RRFFQ69G5FAV" })
+	defer SetInstanceIDGenerator(nil)
+
+	data, err := json.Marshal(BadRequestHTTP("Bad request"))
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	expected := `{"version":1,"message":"Bad request","protocol":"http","code":400,` +
+		`"instance_id":"01ARZ3NDEKTSV4RRFFQ69G5FAV","origin":"github.com/eserg-key/errors.TestJSONSchema"}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON '%s', got '%s'", expected, string(data))
+	}
+}
+
+func TestJSONUnmarshalInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Unsupported Version", `{"version":2,"message":"m","protocol":"http","code":400}`},
+		{"Unknown Protocol", `{"version":1,"message":"m","protocol":"smtp","code":550}`},
+		{"Malformed", `{"version":`},
+		{"Success HTTP Status", `{"version":1,"message":"m","protocol":"http","code":200}`},
+		{"Unknown gRPC Code", `{"version":1,"message":"m","protocol":"grpc","code":17}`},
+		{"Invalid Cause Code", `{"version":1,"message":"m","protocol":"http","code":400,"cause":{"message":"c","code":7}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.data), &Error{}); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+
+	err := json.Unmarshal([]byte(`{"version":1,"message":"m","protocol":"http","code":200}`), &Error{})
+	if !errors.Is(err, ErrInvalidCode) {
+		t.Errorf("Expected ErrInvalidCode, got %v", err)
+	}
+}
+
+func TestFromProtoInvalidCode(t *testing.T) {
+	tests := []struct {
+		name string
+		pb   *errorspb.Error
+	}{
+		{"Unknown HTTP Status", &errorspb.Error{Message: "m", Protocol: errorspb.Protocol_PROTOCOL_HTTP, Code: 999}},
+		{"Success HTTP Status", &errorspb.Error{Message: "m", Protocol: errorspb.Protocol_PROTOCOL_HTTP, Code: 200}},
+		{"Unknown gRPC Code", &errorspb.Error{Message: "m", Protocol: errorspb.Protocol_PROTOCOL_GRPC, Code: 17}},
+		{"Code Without Protocol", &errorspb.Error{Message: "m", Code: 999}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromProto(tt.pb)
+			if StatusGRPC(err) != gRPCUnknown || StatusHTTP(err) != int(hTTPInternalServerError) {
+				t.Errorf("Expected Unknown, got gRPC %d and HTTP %d", StatusGRPC(err), StatusHTTP(err))
+			}
+			if err.Error() != "m" {
+				t.Errorf("Expected message 'm', got '%s'", err.Error())
+			}
+		})
+	}
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"HTTP Forbidden", ForbiddenHTTP("Access denied")},
+		{"gRPC Not Found", NotFoundGRPC("Missing")},
+		{"Context Deadline Exceeded", context.DeadlineExceeded},
+		{"With Reason", WithReason(ConflictHTTP("Duplicate"), "EMAIL_TAKEN")},
+		{"With Domain", New(ProtocolGRPC, 6, "Duplicate", ReasonOption("EMAIL_TAKEN"), DomainOption("users.example.com"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoded := FromProto(ToProto(tt.err))
+
+			if Reason(decoded) != Reason(tt.err) {
+				t.Errorf("Expected reason '%s', got '%s'", Reason(tt.err), Reason(decoded))
+			}
+			if Domain(decoded) != Domain(tt.err) {
+				t.Errorf("Expected domain '%s', got '%s'", Domain(tt.err), Domain(decoded))
+			}
+
+			if decoded.Error() != tt.err.Error() {
+				t.Errorf("Expected message '%s', got '%s'", tt.err.Error(), decoded.Error())
+			}
+			if StatusHTTP(decoded) != StatusHTTP(tt.err) {
+				t.Errorf("Expected HTTP status %d, got %d", StatusHTTP(tt.err), StatusHTTP(decoded))
+			}
+			if StatusGRPC(decoded) != StatusGRPC(tt.err) {
+				t.Errorf("Expected gRPC status %d, got %d", StatusGRPC(tt.err), StatusGRPC(decoded))
+			}
+		})
+	}
+
+	if ToProto(nil) != nil || FromProto(nil) != nil {
+		t.Error("Expected nil for nil input")
+	}
+}
+
+func TestGroup(t *testing.T) {
+	g, ctx := NewGroup(context.Background())
+
+	g.Go(func() error {
+		return NotFoundHTTP("Not found")
+	})
+	g.Go(func() error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	g.Go(func() error {
+		return nil
+	})
+
+	err := g.Wait()
+	if err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+
+	if StatusHTTP(err) != int(hTTPNotFound) {
+		t.Errorf("Expected HTTP status 404, got %d", StatusHTTP(err))
+	}
+	if StatusGRPC(err) != gRPCNotFound {
+		t.Errorf("Expected gRPC status 5, got %d", StatusGRPC(err))
+	}
+}
+
+func TestWrapGroupResult(t *testing.T) {
+	var g Group
+	g.Go(func() error {
+		return NotFoundHTTP("Not found")
+	})
+	g.Go(func() error {
+		return InternalGRPC("Internal")
+	})
+	err := g.Wait()
+
+	if status := StatusHTTP(WrapHTTP(err, 422, "Invalid request")); status != 422 {
+		t.Errorf("Expected HTTP status 422, got %d", status)
+	}
+	if code := StatusGRPC(WrapGRPC(err, 14, "Backend unavailable")); code != gRPCUnavailable {
+		t.Errorf("Expected gRPC status 14, got %d", code)
+	}
+	if status := StatusHTTP(Wrap(err, "Fan-out failed")); status != int(hTTPInternalServerError) {
+		t.Errorf("Expected the most severe HTTP status 500, got %d", status)
+	}
+	if status := StatusHTTP(fmt.Errorf("fan-out: %w", err)); status != int(hTTPInternalServerError) {
+		t.Errorf("Expected the most severe HTTP status 500, got %d", status)
+	}
+
+	var invalid Group
+	invalid.Go(func() error {
+		return UnprocessableEntityHTTP("Invalid item")
+	})
+	err = invalid.Wait()
+	if status := StatusHTTP(Wrap(err, "Validation failed")); status != StatusHTTP(err) || status != int(hTTPUnprocessableEntity) {
+		t.Errorf("Expected HTTP status 422 to survive Wrap, got %d", status)
+	}
+	if protocol := Protocol(Wrap(err, "Validation failed")); protocol != httpProtocol {
+		t.Errorf("Expected the HTTP protocol of the aggregated error, got '%s'", protocol)
+	}
+}
+
+func TestGroupRetryableDoesNotCancel(t *testing.T) {
+	g, ctx := NewGroup(context.Background())
+
+	g.Go(func() error {
+		return UnavailableGRPC("Unavailable")
+	})
+	g.Go(func() error {
+		return InternalServerHTTP("Internal")
+	})
+
+	err := g.Wait()
+	if StatusHTTP(err) != int(hTTPInternalServerError) {
+		t.Errorf("Expected HTTP status 500, got %d", StatusHTTP(err))
+	}
+	if ctx.Err() == nil {
+		t.Error("Expected context to be canceled after Wait")
+	}
+
+	g, ctx = NewGroup(context.Background())
+	g.Go(func() error {
+		return UnavailableGRPC("Unavailable")
+	})
+	g.wg.Wait()
+	if ctx.Err() != nil {
+		t.Error("Expected retryable error not to cancel the context")
+	}
+	g.Wait()
+}
+
+func TestGroupPanic(t *testing.T) {
+	var g Group
+
+	g.Go(func() error {
+		panic("boom")
+	})
+
+	err := g.Wait()
+	if StatusGRPC(err) != gRPCInternal {
+		t.Errorf("Expected gRPC status 13, got %d", StatusGRPC(err))
+	}
+	if len(Stack(err)) == 0 {
+		t.Error("Expected a stack to be captured")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	run := func() (err error) {
+		defer Recover(&err)
+		var values []int
+		_ = values[1]
+		return nil
+	}
+
+	err := run()
+	if StatusHTTP(err) != int(hTTPInternalServerError) {
+		t.Errorf("Expected HTTP status 500, got %d", StatusHTTP(err))
+	}
+
+	var re runtime.Error
+	if !errors.As(err, &re) {
+		t.Error("Expected runtime.Error in the error chain")
+	}
+	if _, ok := PanicValue(err); !ok {
+		t.Error("Expected a panic value")
+	}
+	if len(Stack(err)) == 0 {
+		t.Error("Expected a stack to be captured")
+	}
+}
+
+func TestGo(t *testing.T) {
+	err := <-Go(func() error {
+		panic("boom")
+	})
+
+	value, ok := PanicValue(err)
+	if !ok || value != "boom" {
+		t.Errorf("Expected panic value 'boom', got '%v'", value)
+	}
+	if err.Error() != "panic: boom" {
+		t.Errorf("Expected error message 'panic: boom', got '%s'", err.Error())
+	}
+
+	if err := <-Go(func() error { return nil }); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+}
+
+func TestWithReason(t *testing.T) {
+	err := WithReason(NotFoundHTTP("User not found"), "USER_NOT_FOUND")
+	if Reason(err) != "USER_NOT_FOUND" {
+		t.Errorf("Expected reason 'USER_NOT_FOUND', got '%s'", Reason(err))
+	}
+	if StatusHTTP(err) != int(hTTPNotFound) {
+		t.Errorf("Expected HTTP status 404, got %d", StatusHTTP(err))
+	}
+	if Reason(Wrap(err, "Lookup failed")) != "USER_NOT_FOUND" {
+		t.Error("Expected Wrap to preserve the reason")
+	}
+
+	plain := WithReason(context.DeadlineExceeded, "UPSTREAM_TIMEOUT")
+	if StatusGRPC(plain) != gRPCDeadlineExceeded {
+		t.Errorf("Expected gRPC status 4, got %d", StatusGRPC(plain))
+	}
+	if !errors.Is(plain, context.DeadlineExceeded) {
+		t.Error("Expected the original error to stay in the chain")
+	}
+
+	inner := NotFoundHTTP("missing")
+	wrapper := fmt.Errorf("loading user 7: %w", inner)
+	wrapped := WithReason(wrapper, "USER_NOT_FOUND")
+	if wrapped.Error() != "loading user 7: missing" {
+		t.Errorf("Expected the wrapper text to be kept, got '%s'", wrapped.Error())
+	}
+	if errors.Unwrap(wrapped) != wrapper || !errors.Is(wrapped, inner) {
+		t.Error("Expected the wrapper and the inner error to stay in the chain")
+	}
+	if Reason(wrapped) != "USER_NOT_FOUND" || StatusHTTP(wrapped) != int(hTTPNotFound) {
+		t.Errorf("Unexpected reason '%s' or HTTP status %d", Reason(wrapped), StatusHTTP(wrapped))
+	}
+	if Reason(inner) != "" {
+		t.Error("Expected the inner error to stay unchanged")
+	}
+
+	unprocessable := UnprocessableEntityHTTP("bad")
+	if status := StatusHTTP(WithReason(fmt.Errorf("ctx: %w", unprocessable), "R")); status != int(hTTPUnprocessableEntity) {
+		t.Errorf("Expected HTTP status 422 of the wrapped error, got %d", status)
+	}
+
+	if WithReason(nil, "NONE") != nil {
+		t.Error("Expected nil for nil error")
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Severity
+		client   bool
+		server   bool
+	}{
+		{"Nil Error", nil, SeverityInfo, false, false},
+		{"HTTP Bad Request", BadRequestHTTP("Bad request"), SeverityWarning, true, false},
+		{"gRPC Not Found", NotFoundGRPC("Not found"), SeverityWarning, true, false},
+		{"HTTP Internal Server Error", InternalServerHTTP("Internal"), SeverityError, false, true},
+		{"gRPC Unavailable", UnavailableGRPC("Unavailable"), SeverityError, false, true},
+		{"Context Deadline Exceeded", context.DeadlineExceeded, SeverityError, false, true},
+		{"Override", WithSeverity(NotFoundHTTP("Not found"), SeverityInfo), SeverityInfo, true, false},
+		{"Wrapped Override", Wrap(WithSeverity(InternalGRPC("Internal"), SeverityWarning), "Failed"), SeverityWarning, false, true},
+		{"Override Of Wrapper", WithSeverity(fmt.Errorf("loading user 7: %w", NotFoundHTTP("missing")), SeverityError), SeverityError, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if severity := SeverityOf(tt.err); severity != tt.expected {
+				t.Errorf("Expected severity %s, got %s", tt.expected, severity)
+			}
+			if IsClientError(tt.err) != tt.client {
+				t.Errorf("Expected IsClientError %v, got %v", tt.client, IsClientError(tt.err))
+			}
+			if IsServerError(tt.err) != tt.server {
+				t.Errorf("Expected IsServerError %v, got %v", tt.server, IsServerError(tt.err))
+			}
+		})
+	}
+
+	inner := NotFoundHTTP("missing")
+	wrapper := fmt.Errorf("loading user 7: %w", inner)
+	err := WithSeverity(wrapper, SeverityError)
+	if err.Error() != "loading user 7: missing" || errors.Unwrap(err) != wrapper {
+		t.Errorf("Expected the wrapper to be kept, got '%s'", err.Error())
+	}
+	if SeverityOf(inner) != SeverityWarning {
+		t.Error("Expected the inner error to stay unchanged")
+	}
+
+	unprocessable := WithReason(UnprocessableEntityHTTP("bad"), "R")
+	err = WithSeverity(fmt.Errorf("ctx: %w", unprocessable), SeverityError)
+	if StatusHTTP(err) != int(hTTPUnprocessableEntity) || Reason(err) != "R" {
+		t.Errorf("Expected HTTP status 422 and reason of the wrapped error, got %d and '%s'", StatusHTTP(err), Reason(err))
+	}
+}
+
+func TestFormattedConstructors(t *testing.T) {
+	err := NotFoundHTTPf("User %d not found", 42)
+	if err.Error() != "User 42 not found" {
+		t.Errorf("Expected error message 'User 42 not found', got '%s'", err.Error())
+	}
+	if StatusHTTP(err) != int(hTTPNotFound) {
+		t.Errorf("Expected HTTP status 404, got %d", StatusHTTP(err))
+	}
+
+	err = UnavailableGRPCf("Backend %s: %w", "users", context.DeadlineExceeded)
+	if err.Error() != "Backend users: context deadline exceeded" {
+		t.Errorf("Unexpected error message '%s'", err.Error())
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected %w argument to become the cause")
+	}
+	if StatusGRPC(err) != gRPCUnavailable {
+		t.Errorf("Expected gRPC status 14, got %d", StatusGRPC(err))
+	}
+}
+
+func TestWrapf(t *testing.T) {
+	original := NotFoundGRPC("Missing")
+	wrapped := Wrapf(original, "Load order %d", 7)
+
+	if wrapped.Error() != "Load order 7:Missing" {
+		t.Errorf("Expected wrapped error message 'Load order 7:Missing', got '%s'", wrapped.Error())
+	}
+	if !errors.Is(wrapped, original) {
+		t.Error("Expected the original error to stay in the chain")
+	}
+	if StatusGRPC(wrapped) != gRPCNotFound {
+		t.Errorf("Expected gRPC status 5, got %d", StatusGRPC(wrapped))
+	}
+
+	upstream := ConflictHTTP("Duplicate")
+	wrapped = Wrapf(context.Canceled, "Save failed after %w", upstream)
+	if !errors.Is(wrapped, context.Canceled) || !errors.Is(wrapped, upstream) {
+		t.Error("Expected both the wrapped error and the %w argument in the chain")
+	}
+	if StatusHTTP(wrapped) != int(hTTPConflict) {
+		t.Errorf("Expected HTTP status 409 inherited from %%w argument, got %d", StatusHTTP(wrapped))
+	}
+}
+
+func TestJSONCauseChain(t *testing.T) {
+	inner := WithReason(NotFoundGRPC("Missing"), "USER_NOT_FOUND")
+	data, err := json.Marshal(Wrap(inner, "Load failed"))
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	decoded := &Error{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+
+	var cause *Error
+	if !errors.As(decoded.Unwrap(), &cause) {
+		t.Fatal("Expected the cause to be restored")
+	}
+	if cause.Error() != "Missing" || Reason(cause) != "USER_NOT_FOUND" || StatusGRPC(cause) != gRPCNotFound {
+		t.Errorf("Unexpected cause %q", cause.Error())
+	}
+
+	pb := FromProto(ToProto(Wrap(inner, "Load failed")))
+	if !errors.As(errors.Unwrap(pb), &cause) || cause.Error() != "Missing" {
+		t.Error("Expected the protobuf cause to be restored")
+	}
+
+	// Wrapf with %w transports both the wrapped error and the formatted one
+	wrapped := Wrapf(NotFoundHTTP("nf"), "ctx %w", io.EOF)
+	data, err = json.Marshal(wrapped)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+	decoded = &Error{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+
+	for name, restored := range map[string]error{"JSON": decoded, "protobuf": FromProto(ToProto(wrapped))} {
+		if restored.Error() != "ctx EOF:nf" {
+			t.Errorf("%s: unexpected message %q", name, restored.Error())
+		}
+		list := unwrapAll(restored)
+		if len(list) != 2 {
+			t.Fatalf("%s: expected 2 causes, got %d", name, len(list))
+		}
+		if StatusHTTP(list[0]) != int(hTTPNotFound) || Protocol(list[0]) != httpProtocol || list[0].Error() != "nf" {
+			t.Errorf("%s: unexpected first cause %q", name, list[0].Error())
+		}
+		if list[1].Error() != "EOF" {
+			t.Errorf("%s: unexpected second cause %q", name, list[1].Error())
+		}
+		var er *Error
+		if errors.As(list[1], &er) {
+			t.Errorf("%s: expected the plain cause not to decode as *Error", name)
+		}
+		if errors.Is(restored, ErrUnknown) != errors.Is(wrapped, ErrUnknown) {
+			t.Errorf("%s: expected errors.Is(ErrUnknown) to match the original", name)
+		}
+		if StatusHTTP(restored) != int(hTTPNotFound) {
+			t.Errorf("%s: expected the code of the wrapped error, got %d", name, StatusHTTP(restored))
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	cause := context.DeadlineExceeded
+	detail := &errdetails.ErrorInfo{Reason: "QUOTA", Domain: "example.com"}
+
+	err := New(ProtocolHTTP, 429, "Too many requests",
+		CauseOption(cause),
+		ReasonOption("QUOTA"),
+		MetadataOption(map[string]string{"limit": "100"}),
+		DetailsOption(detail),
+		RetryAfterOption(time.Second),
+		PublicMessageOption("Slow down"),
+		SeverityOption(SeverityInfo),
+		StackOption(),
+	)
+
+	if StatusHTTP(err) != int(hTTPTooManyRequests) {
+		t.Errorf("Expected HTTP status 429, got %d", StatusHTTP(err))
+	}
+	if !errors.Is(err, cause) {
+		t.Error("Expected the cause to be in the chain")
+	}
+	if Reason(err) != "QUOTA" || Metadata(err)["limit"] != "100" || len(Details(err)) != 1 {
+		t.Error("Expected reason, metadata and details to be set")
+	}
+	if d, ok := RetryAfter(err); !ok || d != time.Second || !IsRetryable(err) {
+		t.Errorf("Expected retry after 1s, got %v", d)
+	}
+	if PublicMessage(err) != "Slow down" || SeverityOf(err) != SeverityInfo || len(Stack(err)) == 0 {
+		t.Error("Expected public message, severity and stack to be set")
+	}
+	if PublicMessage(NotFoundHTTP("Not found")) != "Not found" {
+		t.Error("Expected public message to default to the error text")
+	}
+}
+
+func TestNewInvalidCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		protocol ProtocolType
+		code     Code
+	}{
+		{"HTTP Success", ProtocolHTTP, 200},
+		{"HTTP Out Of Range", ProtocolHTTP, 600},
+		{"gRPC Ok", ProtocolGRPC, 0},
+		{"gRPC Out Of Range", ProtocolGRPC, 17},
+		{"Unknown Protocol", "smtp", 550},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := New(tt.protocol, tt.code, "message"); !errors.Is(err, ErrInvalidCode) {
+				t.Errorf("Expected ErrInvalidCode, got %v", err)
+			}
+		})
+	}
+
+	if err := New(ProtocolGRPC, 5, "Not found"); StatusGRPC(err) != gRPCNotFound {
+		t.Errorf("Expected gRPC status 5, got %d", StatusGRPC(err))
+	}
+}
+
+func TestTransportMetadataAndDetails(t *testing.T) {
+	err := New(ProtocolGRPC, 9, "Precondition failed",
+		MetadataOption(map[string]string{"field": "email"}),
+		DetailsOption(&errdetails.ErrorInfo{Reason: "EMAIL_UNVERIFIED"}),
+	)
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Unexpected marshal error: %v", marshalErr)
+	}
+	fromJSON := &Error{}
+	if unmarshalErr := json.Unmarshal(data, fromJSON); unmarshalErr != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", unmarshalErr)
+	}
+
+	for name, decoded := range map[string]error{"JSON": fromJSON, "Proto": FromProto(ToProto(err))} {
+		if Metadata(decoded)["field"] != "email" {
+			t.Errorf("%s: expected metadata to be restored", name)
+		}
+		details := Details(decoded)
+		if len(details) != 1 {
+			t.Fatalf("%s: expected 1 detail, got %d", name, len(details))
+		}
+		if info, ok := details[0].(*errdetails.ErrorInfo); !ok || info.GetReason() != "EMAIL_UNVERIFIED" {
+			t.Errorf("%s: unexpected detail %v", name, details[0])
+		}
+	}
+}
+
+func TestWrapWithCode(t *testing.T) {
+	original := NotFoundGRPC("Order not found")
+
+	wrapped := WrapHTTP(original, 422, "Invalid order reference")
+	if StatusHTTP(wrapped) != int(hTTPUnprocessableEntity) {
+		t.Errorf("Expected HTTP status 422, got %d", StatusHTTP(wrapped))
+	}
+	if !errors.Is(wrapped, original) {
+		t.Error("Expected the original error to stay in the chain")
+	}
+	if StatusGRPC(original) != gRPCNotFound {
+		t.Error("Expected the original error to keep its code")
+	}
+
+	wrapped = WrapGRPC(context.Canceled, 14, "Backend gone")
+	if StatusGRPC(wrapped) != gRPCUnavailable {
+		t.Errorf("Expected gRPC status 14, got %d", StatusGRPC(wrapped))
+	}
+	if wrapped.Error() != "Backend gone:context canceled" {
+		t.Errorf("Unexpected error message '%s'", wrapped.Error())
+	}
+
+	for _, wrapped := range []error{
+		WrapHTTP(original, 200, "Not an error"),
+		WrapHTTP(original, 14, "gRPC code"),
+		WrapGRPC(original, 0, "OK"),
+		WrapGRPC(original, 404, "HTTP status"),
+	} {
+		if !errors.Is(wrapped, ErrInvalidCode) {
+			t.Errorf("Expected ErrInvalidCode, got %v", wrapped)
+		}
+		if !errors.Is(wrapped, original) {
+			t.Error("Expected the original error to stay in the chain of an invalid wrap")
+		}
+	}
+}
+
+func TestWrapNilAndPlainErrors(t *testing.T) {
+	if Wrap(nil, "message") != nil || Wrapf(nil, "message %d", 1) != nil {
+		t.Error("Expected nil when wrapping nil")
+	}
+	if WrapHTTP(nil, 422, "message") != nil || WrapGRPC(nil, 3, "message") != nil {
+		t.Error("Expected nil when wrapping nil with a code")
+	}
+
+	wrapped := Wrap(context.DeadlineExceeded, "Query failed")
+	if StatusHTTP(wrapped) != int(hTTPGatewayTimeout) {
+		t.Errorf("Expected HTTP status 504, got %d", StatusHTTP(wrapped))
+	}
+	if StatusGRPC(wrapped) != gRPCDeadlineExceeded {
+		t.Errorf("Expected gRPC status 4, got %d", StatusGRPC(wrapped))
+	}
+	if !errors.Is(wrapped, context.DeadlineExceeded) {
+		t.Error("Expected the original error to stay in the chain")
+	}
+}
+
+func TestSentinels(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		target   error
+		expected bool
+	}{
+		{"HTTP Not Found", NotFoundHTTP("User not found"), ErrNotFound, true},
+		{"gRPC Not Found", NotFoundGRPC("User not found"), ErrNotFound, true},
+		{"HTTP Gone", GoneHTTP("User deleted"), ErrNotFound, true},
+		{"Wrapped Not Found", Wrapf(NotFoundHTTP("User not found"), "Load %d", 1), ErrNotFound, true},
+		{"Re-coded Keeps Cause", WrapHTTP(NotFoundGRPC("Missing"), 422, "Invalid"), ErrNotFound, true},
+		{"HTTP Forbidden", ForbiddenHTTP("Denied"), ErrPermissionDenied, true},
+		{"Different Class", BadRequestHTTP("Bad request"), ErrNotFound, false},
+		{"Context Canceled", Wrap(context.Canceled, "Stopped"), ErrCanceled, true},
+		{"Plain Error", context.DeadlineExceeded, ErrDeadlineExceeded, false},
+		{"Same Class Not Sentinel", NotFoundHTTP("a"), NotFoundGRPC("b"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errors.Is(tt.err, tt.target) != tt.expected {
+				t.Errorf("Expected errors.Is to return %v", tt.expected)
+			}
+		})
+	}
+}
+
+func TestInstanceID(t *testing.T) {
+	a, b := NotFoundHTTP("User not found"), NotFoundHTTP("User not found")
+	if len(InstanceID(a)) != 26 {
+		t.Errorf("Expected a 26 character ULID, got '%s'", InstanceID(a))
+	}
+	if InstanceID(a) == InstanceID(b) {
+		t.Error("Expected different errors to have different instance IDs")
+	}
+	if InstanceID(Wrap(a, "Load")) != InstanceID(a) {
+		t.Error("Expected the wrapped error to keep the instance ID")
+	}
+
+	SetInstanceIDGenerator(func() string { return "fixed" })
+	defer SetInstanceIDGenerator(nil)
+	if InstanceID(InternalGRPC("Failure")) != "fixed" {
+		t.Error("Expected the injected generator to be used")
+	}
+
+	var decoded Error
+	data, _ := json.Marshal(a)
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if InstanceID(&decoded) != InstanceID(a) || Fingerprint(&decoded) != Fingerprint(a) {
+		t.Error("Expected the instance ID and the fingerprint to survive JSON")
+	}
+	if pb := FromProto(ToProto(a)); InstanceID(pb) != InstanceID(a) || Fingerprint(pb) != Fingerprint(a) {
+		t.Error("Expected the instance ID and the fingerprint to survive protobuf")
+	}
+}
+
+func newUserNotFound(id int) error {
+	return WithReason(NotFoundHTTPf("User %d not found", id), "USER_NOT_FOUND")
+}
+
+func TestFingerprint(t *testing.T) {
+	if Fingerprint(newUserNotFound(1)) != Fingerprint(newUserNotFound(2)) {
+		t.Error("Expected errors from the same origin to share a fingerprint")
+	}
+	if Fingerprint(newUserNotFound(1)) == Fingerprint(WithReason(NotFoundHTTP("User 1 not found"), "USER_NOT_FOUND")) {
+		t.Error("Expected errors from different origins to have different fingerprints")
+	}
+	if Fingerprint(newUserNotFound(1)) == Fingerprint(WithReason(newUserNotFound(1), "ORDER_NOT_FOUND")) {
+		t.Error("Expected errors with different reasons to have different fingerprints")
+	}
+	if Fingerprint(fmt.Errorf("db down")) == Fingerprint(fmt.Errorf("nil deref")) {
+		t.Error("Expected different plain errors to have different fingerprints")
+	}
+	if Fingerprint(fmt.Errorf("db down")) != Fingerprint(fmt.Errorf("db down")) {
+		t.Error("Expected equal plain errors to share a fingerprint")
+	}
+
+	err := <-Go(func() error {
+		panic("boom")
+	})
+	expected := "github.com/eserg-key/errors.TestFingerprint.func1"
+	if origin := err.(*Error).origin; origin != expected {
+		t.Errorf("Expected origin '%s', got '%s'", expected, origin)
+	}
+}
+
+func TestErrorInfo(t *testing.T) {
+	err := WithReason(NotFoundGRPC("User not found"), "USER_NOT_FOUND")
+	info := ErrorInfo(err, "users.example.com")
+
+	if info.GetReason() != "USER_NOT_FOUND" || info.GetDomain() != "users.example.com" {
+		t.Errorf("Unexpected error info %v", info)
+	}
+	if info.GetMetadata()[InstanceIDKey] != InstanceID(err) {
+		t.Errorf("Expected instance ID '%s', got '%s'", InstanceID(err), info.GetMetadata()[InstanceIDKey])
+	}
+	if info.GetMetadata()[FingerprintKey] != Fingerprint(err) {
+		t.Errorf("Expected fingerprint '%s', got '%s'", Fingerprint(err), info.GetMetadata()[FingerprintKey])
+	}
+
+	err = New(ProtocolGRPC, 5, "User not found", DomainOption("accounts.example.com"))
+	if domain := ErrorInfo(err, "users.example.com").GetDomain(); domain != "accounts.example.com" {
+		t.Errorf("Expected the error domain to take precedence, got '%s'", domain)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	ctx := ContextWithTenant(ContextWithRequestID(context.Background(), "req-1"), "acme")
+
+	original := New(ProtocolHTTP, 404, "User not found", MetadataOption(map[string]string{TenantKey: "other"}))
+	err := WithContext(ctx, original)
+	if Metadata(err)[RequestIDKey] != "req-1" {
+		t.Errorf("Expected request ID 'req-1', got '%s'", Metadata(err)[RequestIDKey])
+	}
+	if Metadata(err)[TenantKey] != "other" {
+		t.Errorf("Expected the error metadata to take precedence, got '%s'", Metadata(err)[TenantKey])
+	}
+	if _, ok := Metadata(original)[RequestIDKey]; ok {
+		t.Error("Expected the original error to stay unchanged")
+	}
+	if StatusHTTP(err) != 404 {
+		t.Errorf("Expected HTTP status 404, got %d", StatusHTTP(err))
+	}
+
+	err = WithContext(ctx, context.Canceled)
+	if StatusGRPC(err) != gRPCCanceled || Metadata(err)[TenantKey] != "acme" {
+		t.Errorf("Unexpected error %v with metadata %v", err, Metadata(err))
+	}
+	if WithContext(ctx, nil) != nil {
+		t.Error("Expected nil for nil error")
+	}
+
+	wrapper := fmt.Errorf("loading user 7: %w", original)
+	err = WithContext(ctx, wrapper)
+	if err.Error() != "loading user 7: User not found" || errors.Unwrap(err) != wrapper {
+		t.Errorf("Expected the wrapper to be kept, got '%s'", err.Error())
+	}
+	if Metadata(err)[RequestIDKey] != "req-1" || Metadata(err)[TenantKey] != "other" || StatusHTTP(err) != 404 {
+		t.Errorf("Unexpected metadata %v or HTTP status %d", Metadata(err), StatusHTTP(err))
+	}
+	if _, ok := Metadata(original)[RequestIDKey]; ok {
+		t.Error("Expected the inner error to stay unchanged")
+	}
+	if status := StatusHTTP(WithContext(ctx, fmt.Errorf("ctx: %w", UnprocessableEntityHTTP("bad")))); status != int(hTTPUnprocessableEntity) {
+		t.Errorf("Expected HTTP status 422 of the wrapped error, got %d", status)
+	}
+
+	err = New(ProtocolGRPC, 5, "User not found", ContextOption(ctx))
+	if Metadata(err)[RequestIDKey] != "req-1" {
+		t.Errorf("Expected request ID 'req-1', got '%s'", Metadata(err)[RequestIDKey])
+	}
+	if Metadata(New(ProtocolGRPC, 5, "User not found", ContextOption(context.Background()))) != nil {
+		t.Error("Expected no metadata for an empty context")
+	}
+}
+
+func TestRedactor(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Email", "User john.doe+test@example.com not found", "User [REDACTED] not found"},
+		{"Bearer Token", "Invalid header Bearer eyJhbGciOi.eyJzdWIi.SflKxw==", "Invalid header Bearer [REDACTED]"},
+		{"Card Number", "Card 4111 1111 1111 1111 declined", "Card [REDACTED] declined"},
+		{"Card Number With Dashes", "Card 5500-0000-0000-0004 declined", "Card [REDACTED] declined"},
+		{"Not Luhn Valid", "Order 1234567890123456 not found", "Order 1234567890123456 not found"},
+		{"Short Number", "Order 12345 not found", "Order 12345 not found"},
+		{"Nothing To Redact", "User not found", "User not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if redacted := DefaultRedactor.String(tt.input); redacted != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, redacted)
+			}
+		})
+	}
+}
+
+func TestRedactorMetadata(t *testing.T) {
+	r := &Redactor{
+		Rules:    []RedactRule{RedactPattern(regexp.MustCompile(`\d{3}-\d{2}-\d{4}`), "***")},
+		DenyKeys: []string{"Password"},
+	}
+
+	tests := []struct {
+		name     string
+		key      string
+		value    string
+		expected string
+	}{
+		{"Denied Key", "password", "hunter2", RedactedPlaceholder},
+		{"Custom Rule", "ssn", "SSN 123-45-6789", "SSN ***"},
+		{"Kept Value", "user_id", "42", "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := map[string]string{tt.key: tt.value}
+			if redacted := r.Metadata(metadata)[tt.key]; redacted != tt.expected {
+				t.Errorf("Expected '%s', got '%s'", tt.expected, redacted)
+			}
+			if metadata[tt.key] != tt.value {
+				t.Error("Expected the original metadata to stay unchanged")
+			}
+		})
+	}
+}
+
+func TestRedactedRendering(t *testing.T) {
+	err := New(ProtocolHTTP, 404, "User a@example.com not found",
+		MetadataOption(map[string]string{"token": "secret-value", "user": "b@example.com"}))
+
+	tests := []struct {
+		name     string
+		rendered string
+		raw      string
+	}{
+		{"Error Text", err.Error(), "a@example.com"},
+		{"Metadata", Metadata(err)["token"], "secret-value"},
+	}
+	for _, tt := range tests {
+		t.Run("Raw "+tt.name, func(t *testing.T) {
+			if !strings.Contains(tt.rendered, tt.raw) {
+				t.Errorf("Expected raw data '%s' in '%s'", tt.raw, tt.rendered)
+			}
+		})
+	}
+
+	data, _ := json.Marshal(err)
+	trusted, _ := MarshalTrustedJSON(err.(*Error))
+	info := ErrorInfo(err, "users.example.com")
+	var logged strings.Builder
+	slog.New(slog.NewTextHandler(&logged, nil)).Error("failed", "error", err)
+
+	redacted := []struct {
+		name     string
+		rendered string
+	}{
+		{"Public Message", PublicMessage(err)},
+		{"Public Metadata", fmt.Sprint(PublicMetadata(err))},
+		{"JSON", string(data)},
+		{"Protobuf", fmt.Sprint(ToProto(Wrap(err, "Load failed")))},
+		{"Error Info", fmt.Sprint(info.GetMetadata())},
+		{"Log", logged.String()},
+	}
+	for _, tt := range redacted {
+		t.Run("Redacted "+tt.name, func(t *testing.T) {
+			for _, raw := range []string{"a@example.com", "b@example.com", "secret-value"} {
+				if strings.Contains(tt.rendered, raw) {
+					t.Errorf("Expected '%s' to be redacted in '%s'", raw, tt.rendered)
+				}
+			}
+		})
+	}
+
+	if !strings.Contains(string(trusted), "a@example.com") || !strings.Contains(string(trusted), "secret-value") {
+		t.Errorf("Expected trusted JSON to keep raw data, got '%s'", trusted)
+	}
+	if pb := ToTrustedProto(err); pb.GetMessage() != err.Error() || pb.GetMetadata()["token"] != "secret-value" {
+		t.Errorf("Expected trusted protobuf to keep raw data, got %v", pb)
+	}
+
+	SetRedactor(nil)
+	defer SetRedactor(DefaultRedactor)
+	if PublicMessage(err) != err.Error() {
+		t.Error("Expected no redaction with a nil redactor")
+	}
+}
@@ -0,0 +1,12 @@
+// Command errorslint запускает анализатор errorslint
+// Command errorslint runs the errorslint analyzer
+package main
+
+import (
+	"github.com/eserg-key/errors/errorslint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(errorslint.Analyzer)
+}
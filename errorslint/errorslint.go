@@ -0,0 +1,427 @@
+// Package errorslint находит ошибки, которые покидают обработчики без кода, вызовы Wrap
+// с возможно nil ошибкой и отброшенные результаты конструкторов ошибок
+// Package errorslint finds errors leaving handlers without a code, Wrap calls with
+// a possibly nil error and discarded results of error constructors
+package errorslint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// errorsPath - путь пакета ошибок
+// errorsPath - path of the errors package
+const errorsPath = "github.com/eserg-key/errors"
+
+const doc = `report unclassified errors leaving handlers
+
+The analyzer reports:
+  - HTTP handlers and gRPC methods returning errors built by fmt.Errorf, errors.New
+    and similar functions, which clients receive as 500 or Unknown;
+  - Wrap, Wrapf, WrapHTTP and WrapGRPC called with an error that may be nil,
+    for which they return nil;
+  - discarded results of the constructors of the errors package.`
+
+// Analyzer проверяет использование пакета ошибок
+// Analyzer checks the usage of the errors package
+var Analyzer = &analysis.Analyzer{
+	Name:     "errorslint",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// unclassified lists functions creating errors without a status code
+var unclassified = map[string]bool{
+	"fmt.Errorf":                        true,
+	"errors.New":                        true,
+	"errors.Join":                       true,
+	"github.com/pkg/errors.New":         true,
+	"github.com/pkg/errors.Errorf":      true,
+	"github.com/pkg/errors.Wrap":        true,
+	"github.com/pkg/errors.Wrapf":       true,
+	"github.com/pkg/errors.WithStack":   true,
+	"github.com/pkg/errors.WithMessage": true,
+}
+
+// nonNilConstructors lists functions of other packages that never return nil
+var nonNilConstructors = map[string]bool{
+	"fmt.Errorf":                   true,
+	"errors.New":                   true,
+	"github.com/pkg/errors.New":    true,
+	"github.com/pkg/errors.Errorf": true,
+}
+
+// wrappers lists functions of the errors package returning nil for a nil error
+var wrappers = map[string]bool{
+	"Wrap":     true,
+	"Wrapf":    true,
+	"WrapHTTP": true,
+	"WrapGRPC": true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	filter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+		(*ast.ExprStmt)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	insp.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if fn, ok := pass.TypesInfo.Defs[n.Name].(*types.Func); ok && n.Body != nil && isHandler(fn) {
+				checkReturns(pass, n.Body)
+			}
+		case *ast.FuncLit:
+			if sig, ok := pass.TypesInfo.TypeOf(n).(*types.Signature); ok && isHTTPHandler(sig) {
+				checkReturns(pass, n.Body)
+			}
+		case *ast.ExprStmt:
+			checkDiscarded(pass, n.X)
+		case *ast.AssignStmt:
+			if len(n.Rhs) == 1 && allBlank(n.Lhs) {
+				checkDiscarded(pass, n.Rhs[0])
+			}
+		case *ast.CallExpr:
+			checkWrap(pass, n, stack)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// checkReturns reports returned errors created by unclassified constructors
+func checkReturns(pass *analysis.Pass, body *ast.BlockStmt) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Returns of nested functions belong to them
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) == 0 {
+				return true
+			}
+			call, ok := ast.Unparen(n.Results[len(n.Results)-1]).(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if name := calleeName(pass, call); unclassified[name] {
+				pass.Reportf(call.Pos(), "handler returns an error from %s without a status code; "+
+					"create it with the errors package or Wrap", name)
+			}
+		}
+		return true
+	})
+}
+
+// checkDiscarded reports discarded results of the errors package functions
+func checkDiscarded(pass *analysis.Pass, expr ast.Expr) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+
+	fn := errorsFunc(pass, call)
+	if fn == nil {
+		return
+	}
+	if results := fn.Type().(*types.Signature).Results(); results.Len() > 0 && isError(results.At(results.Len()-1).Type()) {
+		pass.Reportf(call.Pos(), "result of errors.%s is discarded", fn.Name())
+	}
+}
+
+// checkWrap reports Wrap calls whose error may be nil
+func checkWrap(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	fn := errorsFunc(pass, call)
+	if fn == nil || !wrappers[fn.Name()] || len(call.Args) == 0 {
+		return
+	}
+
+	arg := ast.Unparen(call.Args[0])
+	if tv, ok := pass.TypesInfo.Types[arg]; ok && tv.IsNil() {
+		pass.Reportf(call.Pos(), "errors.%s of nil always returns nil", fn.Name())
+		return
+	}
+
+	id, ok := arg.(*ast.Ident)
+	if !ok {
+		return
+	}
+	obj := pass.TypesInfo.Uses[id]
+	if obj == nil || guarded(pass, obj, stack) || assignedNonNil(pass, obj, enclosingBody(stack), map[types.Object]bool{}) {
+		return
+	}
+	pass.Reportf(call.Pos(), "%s may be nil here and errors.%s returns nil for a nil error; "+
+		"check it against nil first", id.Name, fn.Name())
+}
+
+// guarded reports whether the innermost node of the stack runs only when obj is not nil
+func guarded(pass *analysis.Pass, obj types.Object, stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		child := stack[i+1]
+
+		switch parent := stack[i].(type) {
+		case *ast.IfStmt:
+			if child == parent.Body && nonNil(pass, obj, parent.Cond, token.NEQ) {
+				return true
+			}
+			if child == parent.Else && nonNil(pass, obj, parent.Cond, token.EQL) {
+				return true
+			}
+		case *ast.CaseClause:
+			for _, expr := range parent.List {
+				if nonNil(pass, obj, expr, token.NEQ) {
+					return true
+				}
+			}
+		case *ast.BlockStmt:
+			for _, stmt := range parent.List {
+				if stmt == child {
+					break
+				}
+				if ifStmt, ok := stmt.(*ast.IfStmt); ok && nonNil(pass, obj, ifStmt.Cond, token.EQL) && terminates(ifStmt.Body) {
+					return true
+				}
+			}
+		case *ast.FuncDecl, *ast.FuncLit:
+			return false
+		}
+	}
+	return false
+}
+
+// enclosingBody returns the body of the function declaration containing the stack
+func enclosingBody(stack []ast.Node) ast.Node {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if decl, ok := stack[i].(*ast.FuncDecl); ok {
+			return decl.Body
+		}
+	}
+	return nil
+}
+
+// assignedNonNil reports whether every assignment to obj in the body stores an error
+// that cannot be nil and the address of obj is never taken. seen breaks cycles
+// of assignments such as err = Wrap(err, "")
+func assignedNonNil(pass *analysis.Pass, obj types.Object, body ast.Node, seen map[types.Object]bool) bool {
+	if body == nil || seen[obj] {
+		return false
+	}
+	seen[obj] = true
+
+	found, nonNil := false, true
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok || (pass.TypesInfo.Defs[id] != obj && pass.TypesInfo.Uses[id] != obj) {
+					continue
+				}
+				found = true
+				nonNil = nonNil && len(n.Lhs) == len(n.Rhs) && nonNilExpr(pass, n.Rhs[i], body, seen)
+			}
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if pass.TypesInfo.Defs[name] != obj {
+					continue
+				}
+				found = true
+				nonNil = nonNil && len(n.Names) == len(n.Values) && nonNilExpr(pass, n.Values[i], body, seen)
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && isObj(pass, obj, n.X) {
+				nonNil = false
+			}
+		}
+		return true
+	})
+	return found && nonNil
+}
+
+// nonNilExpr reports whether the expression is an error that cannot be nil: a result
+// of a constructor of the errors package, or of a function of the package taking
+// an error, such as Wrap or WithReason, applied to such an error, or a variable
+// holding such errors only
+func nonNilExpr(pass *analysis.Pass, expr ast.Expr, body ast.Node, seen map[types.Object]bool) bool {
+	var call *ast.CallExpr
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		obj := pass.TypesInfo.Uses[expr]
+		return obj != nil && assignedNonNil(pass, obj, body, seen)
+	case *ast.CallExpr:
+		call = expr
+	default:
+		return false
+	}
+	if nonNilConstructors[calleeName(pass, call)] {
+		return true
+	}
+
+	fn := errorsFunc(pass, call)
+	if fn == nil {
+		return false
+	}
+	params := fn.Type().(*types.Signature).Params()
+	if params.Len() == 0 || !isError(params.At(0).Type()) {
+		// FromProto returns nil for a nil message
+		return fn.Name() != "FromProto"
+	}
+	return len(call.Args) > 0 && nonNilExpr(pass, call.Args[0], body, seen)
+}
+
+// nonNil reports whether the condition compares obj with nil using op. For token.NEQ
+// conditions joined by && and errors.Is/errors.As calls on obj also count,
+// for token.EQL conditions joined by ||
+func nonNil(pass *analysis.Pass, obj types.Object, cond ast.Expr, op token.Token) bool {
+	switch cond := ast.Unparen(cond).(type) {
+	case *ast.BinaryExpr:
+		if (op == token.NEQ && cond.Op == token.LAND) || (op == token.EQL && cond.Op == token.LOR) {
+			return nonNil(pass, obj, cond.X, op) || nonNil(pass, obj, cond.Y, op)
+		}
+		if cond.Op != op {
+			return false
+		}
+		return (isObj(pass, obj, cond.X) && isNil(pass, cond.Y)) || (isObj(pass, obj, cond.Y) && isNil(pass, cond.X))
+	case *ast.CallExpr:
+		name := calleeName(pass, cond)
+		return op == token.NEQ && (name == "errors.Is" || name == "errors.As") && len(cond.Args) > 0 && isObj(pass, obj, cond.Args[0])
+	}
+	return false
+}
+
+// terminates reports whether the block ends with a statement leaving it
+func terminates(block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+
+	switch stmt := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return stmt.Tok == token.BREAK || stmt.Tok == token.CONTINUE || stmt.Tok == token.GOTO
+	case *ast.ExprStmt:
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		id, ok := call.Fun.(*ast.Ident)
+		return ok && id.Name == "panic"
+	}
+	return false
+}
+
+// isHandler reports whether the function is an HTTP handler or a gRPC method returning an error
+func isHandler(fn *types.Func) bool {
+	sig := fn.Type().(*types.Signature)
+	return isHTTPHandler(sig) || (sig.Recv() != nil && fn.Exported() && isGRPCMethod(sig))
+}
+
+// isHTTPHandler reports whether the signature is func(http.ResponseWriter, *http.Request) error
+func isHTTPHandler(sig *types.Signature) bool {
+	params, results := sig.Params(), sig.Results()
+	return params.Len() == 2 && results.Len() == 1 && isError(results.At(0).Type()) &&
+		isNamed(params.At(0).Type(), "net/http", "ResponseWriter") && isPointerTo(params.At(1).Type(), "net/http", "Request")
+}
+
+// isGRPCMethod reports whether the signature is a unary gRPC method
+// func(context.Context, *Request) (*Response, error) or a streaming method
+// func(*Request, Stream) error with the stream interface named ...Server.
+// The request must be a pointer to a protobuf message
+func isGRPCMethod(sig *types.Signature) bool {
+	params, results := sig.Params(), sig.Results()
+	if params.Len() != 2 || results.Len() == 0 || !isError(results.At(results.Len()-1).Type()) {
+		return false
+	}
+
+	if results.Len() == 2 {
+		return isNamed(params.At(0).Type(), "context", "Context") && isProtoMessage(params.At(1).Type())
+	}
+	named, ok := params.At(1).Type().(*types.Named)
+	return ok && types.IsInterface(named) && strings.HasSuffix(named.Obj().Name(), "Server") &&
+		isProtoMessage(params.At(0).Type())
+}
+
+// isProtoMessage reports whether the type is a pointer with the ProtoReflect method
+// of generated protobuf messages
+func isProtoMessage(t types.Type) bool {
+	if _, ok := t.(*types.Pointer); !ok {
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "ProtoReflect")
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+// errorsFunc returns the statically called package-level function of the errors package
+func errorsFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != errorsPath || fn.Type().(*types.Signature).Recv() != nil {
+		return nil
+	}
+	return fn
+}
+
+// calleeName returns the qualified name of the statically called function
+func calleeName(pass *analysis.Pass, call *ast.CallExpr) string {
+	fn := typeutil.StaticCallee(pass.TypesInfo, call)
+	if fn == nil || fn.Pkg() == nil {
+		return ""
+	}
+	return fn.Pkg().Path() + "." + fn.Name()
+}
+
+// isObj reports whether the expression refers to obj
+func isObj(pass *analysis.Pass, obj types.Object, expr ast.Expr) bool {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[id] == obj
+}
+
+// isNil reports whether the expression is nil
+func isNil(pass *analysis.Pass, expr ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	return ok && tv.IsNil()
+}
+
+// isError reports whether the type is error
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// isNamed reports whether the type is the named type path.name
+func isNamed(t types.Type, path, name string) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == path && named.Obj().Name() == name
+}
+
+// isPointerTo reports whether the type is a pointer to the named type path.name
+func isPointerTo(t types.Type, path, name string) bool {
+	ptr, ok := t.(*types.Pointer)
+	return ok && isNamed(ptr.Elem(), path, name)
+}
+
+// allBlank reports whether all expressions are the blank identifier
+func allBlank(exprs []ast.Expr) bool {
+	for _, expr := range exprs {
+		if id, ok := expr.(*ast.Ident); !ok || id.Name != "_" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	errs "github.com/eserg-key/errors"
+)
+
+type User struct{}
+
+type GetUserRequest struct{}
+
+// ProtoReflect stands in for the method of generated protobuf messages
+func (*GetUserRequest) ProtoReflect() any { return nil }
+
+type Users_ListServer interface {
+	Send(*User) error
+}
+
+type server struct{}
+
+func (s *server) GetUser(ctx context.Context, req *GetUserRequest) (*User, error) {
+	if req == nil {
+		return nil, fmt.Errorf("empty request") // want `handler returns an error from fmt.Errorf without a status code`
+	}
+	if ctx == nil {
+		return nil, errors.New("no context") // want `handler returns an error from errors.New without a status code`
+	}
+	return nil, errs.NotFoundHTTP("User not found")
+}
+
+func (s *server) ListUsers(req *GetUserRequest, stream Users_ListServer) error {
+	return fmt.Errorf("not implemented") // want `handler returns an error from fmt.Errorf without a status code`
+}
+
+func (s *server) load(ctx context.Context, id int) (*User, error) {
+	return nil, fmt.Errorf("user %d: not found", id)
+}
+
+type Repo struct{}
+
+func (r *Repo) FindUser(ctx context.Context, id int) (string, error) {
+	return "", fmt.Errorf("user %d: not found", id)
+}
+
+func (r *Repo) FindByRequest(ctx context.Context, req GetUserRequest) (*User, error) {
+	return nil, fmt.Errorf("request by value is not a protobuf message")
+}
+
+func (r *Repo) Stream(id int, stream Users_ListServer) error {
+	return fmt.Errorf("user %d: not a streaming request", id)
+}
+
+func handle(w http.ResponseWriter, r *http.Request) error {
+	format := func() error {
+		return fmt.Errorf("nested functions are not handlers")
+	}
+	if r.URL == nil {
+		return fmt.Errorf("no URL: %w", format()) // want `handler returns an error from fmt.Errorf without a status code`
+	}
+	return errs.Wrap(format(), "Format")
+}
+
+var _ = func(w http.ResponseWriter, r *http.Request) error {
+	return errors.New("boom") // want `handler returns an error from errors.New without a status code`
+}
+
+func wrap(ctx context.Context, err error) error {
+	if err != nil {
+		return errs.Wrap(err, "Guarded")
+	}
+	if ctx != nil && err != nil {
+		return errs.Wrapf(err, "Guarded %d", 1)
+	}
+	if errors.Is(err, context.Canceled) {
+		return errs.Wrap(err, "Guarded by errors.Is")
+	}
+	if err == nil {
+		_ = 1
+	} else {
+		return errs.WrapHTTP(err, 422, "Guarded in else")
+	}
+	switch {
+	case err != nil:
+		return errs.Wrap(err, "Guarded by case")
+	}
+
+	_ = errs.Wrap(nil, "Nil")          // want `errors.Wrap of nil always returns nil` `result of errors.Wrap is discarded`
+	return errs.Wrap(err, "Unguarded") // want `err may be nil here and errors.Wrap returns nil for a nil error`
+}
+
+func earlyReturn(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errs.Wrap(err, "Guarded by early return")
+}
+
+func discard() {
+	var err error
+	defer errs.Recover(&err)
+
+	errs.NotFoundHTTP("User not found") // want `result of errors.NotFoundHTTP is discarded`
+	_ = errs.InternalGRPC("Failure")    // want `result of errors.InternalGRPC is discarded`
+	_ = errs.StatusHTTP(err)
+}
+
+func constructed() error {
+	original := errs.NotFoundHTTP("User not found")
+	wrapped := errs.Wrap(original, "Load")
+	plain := fmt.Errorf("plain")
+
+	var reassigned error = errs.NotFoundHTTP("User not found")
+	reassigned = load()
+
+	var captured error
+	defer errs.Recover(&captured)
+
+	_ = errs.Wrap(plain, "Plain")           // want `result of errors.Wrap is discarded`
+	_ = errs.Wrap(reassigned, "Reassigned") // want `reassigned may be nil here` `result of errors.Wrap is discarded`
+	_ = errs.Wrap(captured, "Captured")     // want `captured may be nil here` `result of errors.Wrap is discarded`
+	return errs.Wrap(wrapped, "Wrapped")
+}
+
+func load() error {
+	return nil
+}
@@ -0,0 +1,21 @@
+package errors
+
+type Code uint32
+
+type Error struct{ Message string }
+
+func (e *Error) Error() string { return e.Message }
+
+func NotFoundHTTP(message string) error { return &Error{message} }
+
+func InternalGRPC(message string) error { return &Error{message} }
+
+func Wrap(err error, message string) error { return err }
+
+func Wrapf(err error, format string, args ...any) error { return err }
+
+func WrapHTTP(err error, code Code, message string) error { return err }
+
+func StatusHTTP(err error) int { return 500 }
+
+func Recover(errp *error) {}
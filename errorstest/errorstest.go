@@ -0,0 +1,175 @@
+// Package errorstest содержит утверждения и тестовые серверы для проверки ошибок
+// Package errorstest provides assertions and test servers for checking errors
+package errorstest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+)
+
+// AssertHTTPStatus проверяет HTTP-статус ошибки
+// AssertHTTPStatus checks the HTTP status of the error
+func AssertHTTPStatus(t testing.TB, err error, expected int) bool {
+	t.Helper()
+	if actual := errs.StatusHTTP(err); actual != expected {
+		t.Errorf("Expected HTTP status %d, got %d\n%s", expected, actual, Describe(err))
+		return false
+	}
+	return true
+}
+
+// AssertGRPCCode проверяет gRPC код ошибки
+// AssertGRPCCode checks the gRPC code of the error
+func AssertGRPCCode(t testing.TB, err error, expected errs.Code) bool {
+	t.Helper()
+	if actual := errs.StatusGRPC(err); actual != expected {
+		t.Errorf("Expected gRPC code %d, got %d\n%s", expected, actual, Describe(err))
+		return false
+	}
+	return true
+}
+
+// AssertReason проверяет машиночитаемую причину ошибки
+// AssertReason checks the machine-readable reason of the error
+func AssertReason(t testing.TB, err error, expected string) bool {
+	t.Helper()
+	if actual := errs.Reason(err); actual != expected {
+		t.Errorf("Expected reason '%s', got '%s'\n%s", expected, actual, Describe(err))
+		return false
+	}
+	return true
+}
+
+// AssertHasField проверяет, что метаданные ошибки содержат ключ с указанным значением
+// AssertHasField checks that the metadata of the error contains the key with the given value
+func AssertHasField(t testing.TB, err error, key, expected string) bool {
+	t.Helper()
+	actual, ok := errs.Metadata(err)[key]
+	if !ok {
+		t.Errorf("Expected metadata field '%s'\n%s", key, Describe(err))
+		return false
+	}
+	if actual != expected {
+		t.Errorf("Expected metadata field '%s' to be '%s', got '%s'\n%s", key, expected, actual, Describe(err))
+		return false
+	}
+	return true
+}
+
+// AssertChainContains проверяет, что цепочка ошибки содержит target по errors.Is
+// AssertChainContains checks that the error chain contains target according to errors.Is
+func AssertChainContains(t testing.TB, err, target error) bool {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Errorf("Expected the error chain to contain '%v'\n%s", target, Describe(err))
+		return false
+	}
+	return true
+}
+
+// Matcher проверяет значение. Интерфейс совместим с gomock.Matcher
+// Matcher checks a value. The interface is compatible with gomock.Matcher
+type Matcher interface {
+	Matches(x any) bool
+	String() string
+}
+
+// matcher is a Matcher built from a predicate on errors
+type matcher struct {
+	description string
+	match       func(err error) bool
+}
+
+// Matches reports whether x is an error matching the predicate
+func (m matcher) Matches(x any) bool {
+	err, ok := x.(error)
+	return ok && m.match(err)
+}
+
+// String describes the matcher
+func (m matcher) String() string {
+	return m.description
+}
+
+// HTTPStatus возвращает Matcher ошибок с указанным HTTP-статусом
+// HTTPStatus returns a Matcher of errors with the given HTTP status
+func HTTPStatus(expected int) Matcher {
+	return matcher{fmt.Sprintf("has HTTP status %d", expected), func(err error) bool {
+		return errs.StatusHTTP(err) == expected
+	}}
+}
+
+// GRPCCode возвращает Matcher ошибок с указанным gRPC кодом
+// GRPCCode returns a Matcher of errors with the given gRPC code
+func GRPCCode(expected errs.Code) Matcher {
+	return matcher{fmt.Sprintf("has gRPC code %d", expected), func(err error) bool {
+		return errs.StatusGRPC(err) == expected
+	}}
+}
+
+// Reason возвращает Matcher ошибок с указанной причиной
+// Reason returns a Matcher of errors with the given reason
+func Reason(expected string) Matcher {
+	return matcher{fmt.Sprintf("has reason '%s'", expected), func(err error) bool {
+		return errs.Reason(err) == expected
+	}}
+}
+
+// ChainContains возвращает Matcher ошибок, цепочка которых содержит target
+// ChainContains returns a Matcher of errors whose chain contains target
+func ChainContains(target error) Matcher {
+	return matcher{fmt.Sprintf("contains '%v'", target), func(err error) bool {
+		return errors.Is(err, target)
+	}}
+}
+
+// Describe возвращает многострочное описание ошибки и цепочки её причин
+// Describe returns a multi-line description of the error and its cause chain
+func Describe(err error) string {
+	if err == nil {
+		return "error: <nil>"
+	}
+
+	var builder strings.Builder
+	describe(&builder, err, "")
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// describe writes the description of the error with the given indentation
+func describe(builder *strings.Builder, err error, indent string) {
+	fmt.Fprintf(builder, "%serror: %q (%T)\n", indent, err.Error(), err)
+
+	if er, ok := err.(*errs.Error); ok {
+		fmt.Fprintf(builder, "%s  protocol: %s\n", indent, errs.Protocol(er))
+		fmt.Fprintf(builder, "%s  status: HTTP %d, gRPC %d %s\n", indent, errs.StatusHTTP(er), errs.StatusGRPC(er), errs.CodeName(er))
+		if reason := errs.Reason(er); reason != "" {
+			fmt.Fprintf(builder, "%s  reason: %s\n", indent, reason)
+		}
+
+		metadata := errs.Metadata(er)
+		keys := make([]string, 0, len(metadata))
+		for k := range metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(builder, "%s  metadata.%s: %s\n", indent, k, metadata[k])
+		}
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if cause := u.Unwrap(); cause != nil {
+			describe(builder, cause, indent+"  ")
+		}
+	case interface{ Unwrap() []error }:
+		for _, cause := range u.Unwrap() {
+			describe(builder, cause, indent+"  ")
+		}
+	}
+}
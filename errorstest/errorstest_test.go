@@ -0,0 +1,145 @@
+package errorstest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+)
+
+// recorder records failures instead of failing the test
+type recorder struct {
+	testing.TB
+	messages []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions(t *testing.T) {
+	err := errs.Wrap(errs.New(errs.ProtocolHTTP, 404, "User not found",
+		errs.ReasonOption("USER_NOT_FOUND"),
+		errs.MetadataOption(map[string]string{"user_id": "42"}),
+	), "Load profile")
+
+	tests := []struct {
+		name     string
+		assert   func(t testing.TB) bool
+		expected string
+	}{
+		{"HTTP Status", func(t testing.TB) bool { return AssertHTTPStatus(t, err, 404) }, ""},
+		{"Wrong HTTP Status", func(t testing.TB) bool { return AssertHTTPStatus(t, err, 400) }, "Expected HTTP status 400, got 404"},
+		{"gRPC Code", func(t testing.TB) bool { return AssertGRPCCode(t, err, 5) }, ""},
+		{"Wrong gRPC Code", func(t testing.TB) bool { return AssertGRPCCode(t, err, 3) }, "Expected gRPC code 3, got 5"},
+		{"Reason", func(t testing.TB) bool { return AssertReason(t, err, "USER_NOT_FOUND") }, ""},
+		{"Wrong Reason", func(t testing.TB) bool { return AssertReason(t, err, "ORDER_NOT_FOUND") }, "Expected reason 'ORDER_NOT_FOUND'"},
+		{"Field", func(t testing.TB) bool { return AssertHasField(t, err, "user_id", "42") }, ""},
+		{"Missing Field", func(t testing.TB) bool { return AssertHasField(t, err, "tenant", "acme") }, "Expected metadata field 'tenant'"},
+		{"Wrong Field", func(t testing.TB) bool { return AssertHasField(t, err, "user_id", "7") }, "to be '7', got '42'"},
+		{"Chain", func(t testing.TB) bool { return AssertChainContains(t, err, errs.ErrNotFound) }, ""},
+		{"Missing In Chain", func(t testing.TB) bool { return AssertChainContains(t, err, context.Canceled) }, "Expected the error chain to contain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			ok := tt.assert(r)
+
+			if tt.expected == "" {
+				if !ok || len(r.messages) != 0 {
+					t.Errorf("Expected the assertion to pass, got %v", r.messages)
+				}
+				return
+			}
+			if ok || len(r.messages) != 1 {
+				t.Fatalf("Expected the assertion to fail once, got %v", r.messages)
+			}
+			if !strings.Contains(r.messages[0], tt.expected) || !strings.Contains(r.messages[0], "reason: USER_NOT_FOUND") {
+				t.Errorf("Unexpected failure message:\n%s", r.messages[0])
+			}
+		})
+	}
+}
+
+func TestMatchers(t *testing.T) {
+	err := errs.WithReason(errs.NotFoundGRPC("User not found"), "USER_NOT_FOUND")
+
+	tests := []struct {
+		name     string
+		matcher  Matcher
+		value    any
+		expected bool
+	}{
+		{"HTTP Status", HTTPStatus(404), err, true},
+		{"gRPC Code", GRPCCode(5), err, true},
+		{"Reason", Reason("USER_NOT_FOUND"), err, true},
+		{"Chain", ChainContains(errs.ErrNotFound), err, true},
+		{"Different Status", HTTPStatus(500), err, false},
+		{"Not An Error", HTTPStatus(404), "User not found", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.matcher.Matches(tt.value) != tt.expected {
+				t.Errorf("Expected matcher '%s' to return %v", tt.matcher, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	err := errs.Wrap(errs.WithReason(errs.NotFoundHTTP("User not found"), "USER_NOT_FOUND"), "Load profile")
+
+	expected := `error: "Load profile:User not found" (*errors.Error)
+  protocol: http
+  status: HTTP 404, gRPC 5 NOT_FOUND
+  reason: USER_NOT_FOUND
+  error: "User not found" (*errors.Error)
+    protocol: http
+    status: HTTP 404, gRPC 5 NOT_FOUND
+    reason: USER_NOT_FOUND`
+	if actual := Describe(err); actual != expected {
+		t.Errorf("Expected description:\n%s\ngot:\n%s", expected, actual)
+	}
+	if Describe(nil) != "error: <nil>" {
+		t.Errorf("Unexpected description of nil: %s", Describe(nil))
+	}
+}
+
+func TestHTTPServer(t *testing.T) {
+	s := NewHTTPServer(t, errs.WithReason(errs.ConflictHTTP("Email taken"), "EMAIL_TAKEN"))
+
+	err := s.Get("/users")
+	AssertHTTPStatus(t, err, 409)
+	AssertReason(t, err, "EMAIL_TAKEN")
+
+	s.SetError(context.DeadlineExceeded)
+	AssertHTTPStatus(t, s.Get("/users"), 504)
+
+	s.SetError(nil)
+	if err := s.Get("/users"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestGRPCServer(t *testing.T) {
+	s := NewGRPCServer(t, errs.New(errs.ProtocolGRPC, 5, "User not found",
+		errs.ReasonOption("USER_NOT_FOUND"),
+		errs.MetadataOption(map[string]string{"user_id": "42"}),
+	))
+
+	err := s.Invoke(context.Background(), "/users.v1.Users/Get")
+	AssertGRPCCode(t, err, 5)
+	AssertReason(t, err, "USER_NOT_FOUND")
+	AssertHasField(t, err, "user_id", "42")
+
+	s.SetError(nil)
+	if err := s.Invoke(context.Background(), "/users.v1.Users/Get"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package errorstest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Domain - домен ErrorInfo в ответах тестового gRPC-сервера
+// Domain - ErrorInfo domain in responses of the test gRPC server
+const Domain = "errorstest"
+
+// HTTPServer - тестовый HTTP-сервер, отвечающий на любой запрос заданной ошибкой
+// в виде JSON со статусом StatusHTTP
+// HTTPServer is a test HTTP server answering any request with the configured error
+// as JSON with the StatusHTTP status
+type HTTPServer struct {
+	*httptest.Server
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewHTTPServer запускает HTTP-сервер, который останавливается по завершении теста
+// NewHTTPServer starts an HTTP server that is stopped when the test finishes
+func NewHTTPServer(t testing.TB, err error) *HTTPServer {
+	s := &HTTPServer{err: err}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// SetError заменяет ошибку, возвращаемую сервером. nil означает успешный ответ
+// SetError replaces the error returned by the server. nil means a successful response
+func (s *HTTPServer) SetError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Get выполняет GET-запрос и восстанавливает ошибку из ответа
+// Get performs a GET request and restores the error from the response
+func (s *HTTPServer) Get(path string) error {
+	resp, err := s.Client().Get(s.URL + path)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 400 {
+		return nil
+	}
+
+	var er errs.Error
+	if err := json.NewDecoder(resp.Body).Decode(&er); err != nil {
+		return err
+	}
+	return &er
+}
+
+// serveHTTP writes the configured error
+func (s *HTTPServer) serveHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	err := s.err
+	s.mu.Unlock()
+
+	if err == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var er *errs.Error
+	if !errors.As(err, &er) {
+		er = errs.New(errs.ProtocolGRPC, errs.StatusGRPC(err), err.Error()).(*errs.Error)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(errs.StatusHTTP(err))
+	_ = json.NewEncoder(w).Encode(er)
+}
+
+// GRPCServer - тестовый gRPC-сервер в памяти, отвечающий на вызов любого метода
+// заданной ошибкой со статусом StatusGRPC и деталью ErrorInfo
+// GRPCServer is an in-memory test gRPC server answering a call of any method
+// with the configured error with the StatusGRPC status and an ErrorInfo detail
+type GRPCServer struct {
+	// Conn - клиентское соединение с сервером
+	// Conn is the client connection to the server
+	Conn *grpc.ClientConn
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewGRPCServer запускает gRPC-сервер, который останавливается по завершении теста
+// NewGRPCServer starts a gRPC server that is stopped when the test finishes
+func NewGRPCServer(t testing.TB, err error) *GRPCServer {
+	t.Helper()
+
+	s := &GRPCServer{err: err}
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.UnknownServiceHandler(s.handle))
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, dialErr := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if dialErr != nil {
+		t.Fatalf("Unexpected dial error: %v", dialErr)
+	}
+	s.Conn = conn
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+	})
+	return s
+}
+
+// SetError заменяет ошибку, возвращаемую сервером. nil означает успешный ответ
+// SetError replaces the error returned by the server. nil means a successful response
+func (s *GRPCServer) SetError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Invoke вызывает метод сервера и восстанавливает ошибку из gRPC-статуса
+// Invoke calls the method of the server and restores the error from the gRPC status
+func (s *GRPCServer) Invoke(ctx context.Context, method string) error {
+	err := s.Conn.Invoke(ctx, method, &emptypb.Empty{}, &emptypb.Empty{})
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var opts []errs.Option
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			opts = append(opts, errs.ReasonOption(info.GetReason()), errs.MetadataOption(info.GetMetadata()))
+		}
+	}
+	return errs.New(errs.ProtocolGRPC, errs.Code(st.Code()), st.Message(), opts...)
+}
+
+// handle answers any call with the configured error
+func (s *GRPCServer) handle(_ any, stream grpc.ServerStream) error {
+	s.mu.Lock()
+	err := s.err
+	s.mu.Unlock()
+
+	if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+		return err
+	}
+	if err == nil {
+		return stream.SendMsg(&emptypb.Empty{})
+	}
+
+	st := status.New(codes.Code(errs.StatusGRPC(err)), errs.PublicMessage(err))
+	if withInfo, detailsErr := st.WithDetails(errs.ErrorInfo(err, Domain)); detailsErr == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
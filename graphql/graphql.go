@@ -0,0 +1,116 @@
+// Package graphql преобразует ошибки в формат ошибок спецификации GraphQL
+// Package graphql converts errors into the GraphQL specification error format
+package graphql
+
+import (
+	"errors"
+
+	errs "github.com/eserg-key/errors"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Location - позиция в тексте запроса GraphQL
+// Location - position in the GraphQL request text
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error - ошибка в формате спецификации GraphQL
+// Error - error in the GraphQL specification format
+type Error struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// Extension keys
+const (
+	CodeKey       = "code"
+	StatusKey     = "status"
+	ReasonKey     = "reason"
+	MetadataKey   = "metadata"
+	RetryableKey  = "retryable"
+	RetryAfterKey = "retryAfterSeconds"
+)
+
+// NewError преобразует ошибку в ошибку GraphQL. Сообщением становится PublicMessage,
+// в extensions записываются имя gRPC кода, HTTP-статус, причина, метаданные и
+// признаки повтора
+// NewError converts the error into a GraphQL error. The message is PublicMessage,
+// extensions contain the gRPC code name, the HTTP status, the reason, the metadata
+// and the retry hints
+func NewError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	extensions := map[string]any{
+		CodeKey:      errs.CodeName(err),
+		StatusKey:    errs.StatusHTTP(err),
+		RetryableKey: errs.IsRetryable(err),
+	}
+	if reason := errs.Reason(err); reason != "" {
+		extensions[ReasonKey] = reason
+	}
+	if metadata := errs.PublicMetadata(err); len(metadata) > 0 {
+		extensions[MetadataKey] = metadata
+	}
+	if d, ok := errs.RetryAfter(err); ok {
+		extensions[RetryAfterKey] = d.Seconds()
+	}
+
+	return &Error{Message: errs.PublicMessage(err), Extensions: extensions}
+}
+
+// WithPath задает путь к полю ответа, вызвавшему ошибку
+// WithPath sets the path to the response field that caused the error
+func (e *Error) WithPath(path ...any) *Error {
+	e.Path = path
+	return e
+}
+
+// WithLocation добавляет позицию в тексте запроса
+// WithLocation adds a position in the request text
+func (e *Error) WithLocation(line, column int) *Error {
+	e.Locations = append(e.Locations, Location{Line: line, Column: column})
+	return e
+}
+
+// FormatErrors преобразует список ошибок в ошибки GraphQL. Агрегированные ошибки
+// (например, из errors.Group) раскрываются в отдельные элементы, nil пропускаются.
+// Агрегированные ошибки внутри *errors.Error, например после WrapHTTP, не раскрываются,
+// чтобы сохранить код и сообщение обертки
+// FormatErrors converts a list of errors into GraphQL errors. Aggregated errors
+// (e.g. from errors.Group) are expanded into separate entries, nil values are skipped.
+// Aggregated errors inside an *errors.Error, e.g. after WrapHTTP, are not expanded
+// to keep the code and the message of the wrapper
+func FormatErrors(list ...error) []*Error {
+	var result []*Error
+	for _, err := range list {
+		if me := aggregated(err); me != nil {
+			result = append(result, FormatErrors(me.Errors...)...)
+			continue
+		}
+		if err != nil {
+			result = append(result, NewError(err))
+		}
+	}
+	return result
+}
+
+// aggregated returns the non-empty *multierror.Error found in the chain before any *errors.Error
+func aggregated(err error) *multierror.Error {
+	for cur := err; cur != nil; cur = errors.Unwrap(cur) {
+		switch e := cur.(type) {
+		case *errs.Error:
+			return nil
+		case *multierror.Error:
+			if len(e.Errors) > 0 {
+				return e
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errs "github.com/eserg-key/errors"
+	"github.com/hashicorp/go-multierror"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestFormatErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		errors []*Error
+	}{
+		{"not_found", []*Error{
+			NewError(errs.New(errs.ProtocolHTTP, 404, "User 42 not found",
+				errs.ReasonOption("USER_NOT_FOUND"),
+				errs.MetadataOption(map[string]string{"id": "42"}),
+				errs.PublicMessageOption("User not found"),
+			)).WithPath("user", "profile").WithLocation(2, 3),
+		}},
+		{"rate_limited", []*Error{
+			NewError(errs.New(errs.ProtocolGRPC, 8, "Quota exceeded", errs.RetryAfterOption(30*time.Second))).WithPath("orders", 1),
+		}},
+		{"plain", FormatErrors(context.DeadlineExceeded, nil)},
+		{"aggregate", FormatErrors(multierror.Append(nil, errs.BadRequestHTTP("Bad request"), errs.UnavailableGRPC("Unavailable")))},
+		{"wrapped_aggregate", FormatErrors(errs.WrapHTTP(
+			multierror.Append(nil, errs.BadRequestHTTP("Bad request"), errs.UnavailableGRPC("Unavailable")),
+			422, "Invalid batch",
+		))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.MarshalIndent(map[string]any{"errors": tt.errors}, "", "  ")
+			if err != nil {
+				t.Fatalf("Unexpected marshal error: %v", err)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(expected) {
+				t.Errorf("Output does not match %s:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestNewErrorNil(t *testing.T) {
+	if NewError(nil) != nil {
+		t.Error("Expected nil for nil error")
+	}
+}
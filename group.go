@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"context"
+	"github.com/hashicorp/go-multierror"
+	"sync"
+)
+
+// Group выполняет функции параллельно, собирает все их ошибки и отменяет
+// контекст при первой ошибке, которую нельзя повторить.
+// Нулевое значение Group готово к использованию и не отменяет контекст
+// Group runs functions concurrently, collects all of their errors and cancels
+// the context on the first error that cannot be retried.
+// A zero Group is ready to use and does not cancel a context
+type Group struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	result *multierror.Error
+}
+
+// NewGroup создает Group и производный контекст, который отменяется при первой
+// ошибке, которую нельзя повторить, или после возврата Wait
+// NewGroup creates a Group and a derived context that is canceled on the first
+// error that cannot be retried or once Wait returns
+func NewGroup(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go запускает f в отдельной горутине. Паника в f преобразуется в ошибку
+// с gRPC кодом 13 и стеком вызовов
+// Go runs f in a separate goroutine. A panic in f is converted into an error
+// with gRPC code 13 and the call stack
+func (g *Group) Go(f func() error) {
+	g.wg.Add(1)
+
+	go func() {
+		defer g.wg.Done()
+
+		err := callRecover(f)
+		if err == nil {
+			return
+		}
+
+		g.mu.Lock()
+		g.result = multierror.Append(g.result, err)
+		g.mu.Unlock()
+
+		if g.cancel != nil && !IsRetryable(err) {
+			g.cancel()
+		}
+	}()
+}
+
+// Wait ожидает завершения всех функций и возвращает агрегированную ошибку или nil.
+// Статус агрегированной ошибки равен статусу самой серьезной из ошибок
+// Wait waits for all functions to finish and returns the aggregated error or nil.
+// The status of the aggregated error is the status of the most severe error
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return g.result.ErrorOrNil()
+}
@@ -3,7 +3,14 @@ package errors
 // newHTTP создает новую ошибку с заданным сообщением и HTTP-статусом
 // newHTTP creates a new error with the specified message and HTTP status
 func newGRPCError(message string, code Code) error {
-	return &Error{Message: message, code: code, typeProtocol: grpcProtocol}
+	return (&Error{Message: message, code: code, typeProtocol: grpcProtocol}).stamp()
+}
+
+// newGRPCErrorf создает новую ошибку с форматированным сообщением и gRPC кодом
+// newGRPCErrorf creates a new error with a formatted message and gRPC code
+func newGRPCErrorf(code Code, format string, args ...any) error {
+	message, cause := formatCause(format, args...)
+	return (&Error{Message: message, code: code, typeProtocol: grpcProtocol, cause: cause}).stamp()
 }
 
 // CanceledGRPC создает ошибку с gRPC кодом 1
@@ -12,92 +19,188 @@ func CanceledGRPC(message string) error {
 	return newGRPCError(message, gRPCCanceled)
 }
 
+// CanceledGRPCf создает ошибку с gRPC кодом 1 с форматированным сообщением
+// CanceledGRPCf creates an error with gRPC code 1 with a formatted message
+func CanceledGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCCanceled, format, args...)
+}
+
 // UnknownGRPC создает ошибку с gRPC кодом 2
 // UnknownGRPC creates an error with gRPC code 2
 func UnknownGRPC(message string) error {
 	return newGRPCError(message, gRPCUnknown)
 }
 
+// UnknownGRPCf создает ошибку с gRPC кодом 2 с форматированным сообщением
+// UnknownGRPCf creates an error with gRPC code 2 with a formatted message
+func UnknownGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCUnknown, format, args...)
+}
+
 // InvalidArgumentGRPC создает ошибку с gRPC кодом 3
 // InvalidArgumentGRPC creates an error with gRPC code 3
 func InvalidArgumentGRPC(message string) error {
 	return newGRPCError(message, gRPCInvalidArgument)
 }
 
+// InvalidArgumentGRPCf создает ошибку с gRPC кодом 3 с форматированным сообщением
+// InvalidArgumentGRPCf creates an error with gRPC code 3 with a formatted message
+func InvalidArgumentGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCInvalidArgument, format, args...)
+}
+
 // DeadlineExceededGRPC создает ошибку с gRPC кодом 4
 // DeadlineExceededGRPC creates an error with gRPC code 4
 func DeadlineExceededGRPC(message string) error {
 	return newGRPCError(message, gRPCDeadlineExceeded)
 }
 
+// DeadlineExceededGRPCf создает ошибку с gRPC кодом 4 с форматированным сообщением
+// DeadlineExceededGRPCf creates an error with gRPC code 4 with a formatted message
+func DeadlineExceededGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCDeadlineExceeded, format, args...)
+}
+
 // NotFoundGRPC создает ошибку с gRPC кодом 5
 // NotFoundGRPC creates an error with gRPC code 5
 func NotFoundGRPC(message string) error {
 	return newGRPCError(message, gRPCNotFound)
 }
 
+// NotFoundGRPCf создает ошибку с gRPC кодом 5 с форматированным сообщением
+// NotFoundGRPCf creates an error with gRPC code 5 with a formatted message
+func NotFoundGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCNotFound, format, args...)
+}
+
 // AlreadyExistsGRPC создает ошибку с gRPC кодом 6
 // AlreadyExistsGRPC creates an error with gRPC code 6
 func AlreadyExistsGRPC(message string) error {
 	return newGRPCError(message, gRPCAlreadyExists)
 }
 
+// AlreadyExistsGRPCf создает ошибку с gRPC кодом 6 с форматированным сообщением
+// AlreadyExistsGRPCf creates an error with gRPC code 6 with a formatted message
+func AlreadyExistsGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCAlreadyExists, format, args...)
+}
+
 // PermissionDeniedGRPC создает ошибку с gRPC кодом 7
 // PermissionDeniedGRPC creates an error with gRPC code 7
 func PermissionDeniedGRPC(message string) error {
 	return newGRPCError(message, gRPCPermissionDenied)
 }
 
+// PermissionDeniedGRPCf создает ошибку с gRPC кодом 7 с форматированным сообщением
+// PermissionDeniedGRPCf creates an error with gRPC code 7 with a formatted message
+func PermissionDeniedGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCPermissionDenied, format, args...)
+}
+
 // ResourceExhaustedGRPC создает ошибку с gRPC кодом 8
 // ResourceExhaustedGRPC creates an error with gRPC code 8
 func ResourceExhaustedGRPC(message string) error {
 	return newGRPCError(message, gRPCResourceExhausted)
 }
 
+// ResourceExhaustedGRPCf создает ошибку с gRPC кодом 8 с форматированным сообщением
+// ResourceExhaustedGRPCf creates an error with gRPC code 8 with a formatted message
+func ResourceExhaustedGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCResourceExhausted, format, args...)
+}
+
 // FailedPreconditionGRPC создает ошибку с gRPC кодом 9
 // FailedPreconditionGRPC creates an error with gRPC code 9
 func FailedPreconditionGRPC(message string) error {
 	return newGRPCError(message, gRPCFailedPrecondition)
 }
 
+// FailedPreconditionGRPCf создает ошибку с gRPC кодом 9 с форматированным сообщением
+// FailedPreconditionGRPCf creates an error with gRPC code 9 with a formatted message
+func FailedPreconditionGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCFailedPrecondition, format, args...)
+}
+
 // AbortedGRPC создает ошибку с gRPC кодом 10
 // AbortedGRPC creates an error with gRPC code 10
 func AbortedGRPC(message string) error {
 	return newGRPCError(message, gRPCAborted)
 }
 
+// AbortedGRPCf создает ошибку с gRPC кодом 10 с форматированным сообщением
+// AbortedGRPCf creates an error with gRPC code 10 with a formatted message
+func AbortedGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCAborted, format, args...)
+}
+
 // OutOfRangeGRPC создает ошибку с gRPC кодом 11
 // OutOfRangeGRPC creates an error with gRPC code 11
 func OutOfRangeGRPC(message string) error {
 	return newGRPCError(message, gRPCOutOfRange)
 }
 
+// OutOfRangeGRPCf создает ошибку с gRPC кодом 11 с форматированным сообщением
+// OutOfRangeGRPCf creates an error with gRPC code 11 with a formatted message
+func OutOfRangeGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCOutOfRange, format, args...)
+}
+
 // UnimplementedGRPC создает ошибку с gRPC кодом 12
 // UnimplementedGRPC creates an error with gRPC code 12
 func UnimplementedGRPC(message string) error {
 	return newGRPCError(message, gRPCUnimplemented)
 }
 
+// UnimplementedGRPCf создает ошибку с gRPC кодом 12 с форматированным сообщением
+// UnimplementedGRPCf creates an error with gRPC code 12 with a formatted message
+func UnimplementedGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCUnimplemented, format, args...)
+}
+
 // InternalGRPC создает ошибку с gRPC кодом 13
 // InternalGRPC creates an error with gRPC code 13
 func InternalGRPC(message string) error {
 	return newGRPCError(message, gRPCInternal)
 }
 
+// InternalGRPCf создает ошибку с gRPC кодом 13 с форматированным сообщением
+// InternalGRPCf creates an error with gRPC code 13 with a formatted message
+func InternalGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCInternal, format, args...)
+}
+
 // UnavailableGRPC создает ошибку с gRPC кодом 14
 // UnavailableGRPC creates an error with gRPC code 14
 func UnavailableGRPC(message string) error {
 	return newGRPCError(message, gRPCUnavailable)
 }
 
+// UnavailableGRPCf создает ошибку с gRPC кодом 14 с форматированным сообщением
+// UnavailableGRPCf creates an error with gRPC code 14 with a formatted message
+func UnavailableGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCUnavailable, format, args...)
+}
+
 // DataLossGRPC создает ошибку с gRPC кодом 15
 // DataLossGRPC creates an error with gRPC code 15
 func DataLossGRPC(message string) error {
 	return newGRPCError(message, gRPCDataLoss)
 }
 
+// DataLossGRPCf создает ошибку с gRPC кодом 15 с форматированным сообщением
+// DataLossGRPCf creates an error with gRPC code 15 with a formatted message
+func DataLossGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCDataLoss, format, args...)
+}
+
 // UnauthenticatedGRPC создает ошибку с gRPC кодом 16
 // UnauthenticatedGRPC creates an error with gRPC code 16
 func UnauthenticatedGRPC(message string) error {
 	return newGRPCError(message, gRPCUnauthenticated)
 }
+
+// UnauthenticatedGRPCf создает ошибку с gRPC кодом 16 с форматированным сообщением
+// UnauthenticatedGRPCf creates an error with gRPC code 16 with a formatted message
+func UnauthenticatedGRPCf(format string, args ...any) error {
+	return newGRPCErrorf(gRPCUnauthenticated, format, args...)
+}
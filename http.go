@@ -3,7 +3,14 @@ package errors
 // newHTTP создает новую ошибку с заданным сообщением и HTTP-статусом
 // newHTTP creates a new error with the specified message and HTTP status
 func newHTTPError(message string, code Code) error {
-	return &Error{Message: message, code: code, typeProtocol: httpProtocol}
+	return (&Error{Message: message, code: code, typeProtocol: httpProtocol}).stamp()
+}
+
+// newHTTPErrorf создает новую ошибку с форматированным сообщением и HTTP-статусом
+// newHTTPErrorf creates a new error with a formatted message and HTTP status
+func newHTTPErrorf(code Code, format string, args ...any) error {
+	message, cause := formatCause(format, args...)
+	return (&Error{Message: message, code: code, typeProtocol: httpProtocol, cause: cause}).stamp()
 }
 
 // BadRequestHTTP создает ошибку с HTTP-статусом 400 (Bad Request)
@@ -12,158 +19,320 @@ func BadRequestHTTP(message string) error {
 	return newHTTPError(message, hTTPBadRequest)
 }
 
+// BadRequestHTTPf создает ошибку с HTTP-статусом 400 (Bad Request) с форматированным сообщением
+// BadRequestHTTPf creates an error with HTTP status 400 (Bad Request) with a formatted message
+func BadRequestHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPBadRequest, format, args...)
+}
+
 // UnauthorizedHTTP создает ошибку с HTTP-статусом 401 (Unauthorized)
 // UnauthorizedHTTP creates an error with HTTP status 401 (Unauthorized)
 func UnauthorizedHTTP(message string) error {
 	return newHTTPError(message, hTTPUnauthorized)
 }
 
+// UnauthorizedHTTPf создает ошибку с HTTP-статусом 401 (Unauthorized) с форматированным сообщением
+// UnauthorizedHTTPf creates an error with HTTP status 401 (Unauthorized) with a formatted message
+func UnauthorizedHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPUnauthorized, format, args...)
+}
+
 // PaymentRequiredHTTP создает ошибку с HTTP-статусом 402 (Payment Required)
 // PaymentRequiredHTTP creates an error with HTTP status 402 (Payment Required)
 func PaymentRequiredHTTP(message string) error {
 	return newHTTPError(message, hTTPPaymentRequired)
 }
 
+// PaymentRequiredHTTPf создает ошибку с HTTP-статусом 402 (Payment Required) с форматированным сообщением
+// PaymentRequiredHTTPf creates an error with HTTP status 402 (Payment Required) with a formatted message
+func PaymentRequiredHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPPaymentRequired, format, args...)
+}
+
 // ForbiddenHTTP создает ошибку с HTTP-статусом 403 (Forbidden)
 // ForbiddenHTTP creates an error with HTTP status 403 (Forbidden)
 func ForbiddenHTTP(message string) error {
 	return newHTTPError(message, hTTPForbidden)
 }
 
+// ForbiddenHTTPf создает ошибку с HTTP-статусом 403 (Forbidden) с форматированным сообщением
+// ForbiddenHTTPf creates an error with HTTP status 403 (Forbidden) with a formatted message
+func ForbiddenHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPForbidden, format, args...)
+}
+
 // NotFoundHTTP создает ошибку с HTTP-статусом 404 (Not Found)
 // NotFoundHTTP creates an error with HTTP status 404 (Not Found)
 func NotFoundHTTP(message string) error {
 	return newHTTPError(message, hTTPNotFound)
 }
 
+// NotFoundHTTPf создает ошибку с HTTP-статусом 404 (Not Found) с форматированным сообщением
+// NotFoundHTTPf creates an error with HTTP status 404 (Not Found) with a formatted message
+func NotFoundHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPNotFound, format, args...)
+}
+
 // MethodNotAllowedHTTP создает ошибку с HTTP-статусом 405 (Method Not Allowed)
 // MethodNotAllowedHTTP creates an error with HTTP status 405 (Method Not Allowed)
 func MethodNotAllowedHTTP(message string) error {
 	return newHTTPError(message, hTTPMethodNotAllowed)
 }
 
+// MethodNotAllowedHTTPf создает ошибку с HTTP-статусом 405 (Method Not Allowed) с форматированным сообщением
+// MethodNotAllowedHTTPf creates an error with HTTP status 405 (Method Not Allowed) with a formatted message
+func MethodNotAllowedHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPMethodNotAllowed, format, args...)
+}
+
 // NotAcceptableHTTP создает ошибку с HTTP-статусом 406 (Not Acceptable)
 // NotAcceptableHTTP creates an error with HTTP status 406 (Not Acceptable)
 func NotAcceptableHTTP(message string) error {
 	return newHTTPError(message, hTTPNotAcceptable)
 }
 
+// NotAcceptableHTTPf создает ошибку с HTTP-статусом 406 (Not Acceptable) с форматированным сообщением
+// NotAcceptableHTTPf creates an error with HTTP status 406 (Not Acceptable) with a formatted message
+func NotAcceptableHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPNotAcceptable, format, args...)
+}
+
 // ProxyAuthRequiredHTTP создает ошибку с HTTP-статусом 407 (Proxy Authentication Required)
 // ProxyAuthRequiredHTTP creates an error with HTTP status 407 (Proxy Authentication Required)
 func ProxyAuthRequiredHTTP(message string) error {
 	return newHTTPError(message, hTTPStatusProxyAuthRequired)
 }
 
+// ProxyAuthRequiredHTTPf создает ошибку с HTTP-статусом 407 (Proxy Authentication Required) с форматированным сообщением
+// ProxyAuthRequiredHTTPf creates an error with HTTP status 407 (Proxy Authentication Required) with a formatted message
+func ProxyAuthRequiredHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPStatusProxyAuthRequired, format, args...)
+}
+
 // RequestTimeoutHTTP создает ошибку с HTTP-статусом 408 (Request Timeout)
 // RequestTimeoutHTTP creates an error with HTTP status 408 (Request Timeout)
 func RequestTimeoutHTTP(message string) error {
 	return newHTTPError(message, hTTPRequestTimeout)
 }
 
+// RequestTimeoutHTTPf создает ошибку с HTTP-статусом 408 (Request Timeout) с форматированным сообщением
+// RequestTimeoutHTTPf creates an error with HTTP status 408 (Request Timeout) with a formatted message
+func RequestTimeoutHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPRequestTimeout, format, args...)
+}
+
 // ConflictHTTP создает ошибку с HTTP-статусом 409 (Conflict)
 // ConflictHTTP creates an error with HTTP status 409 (Conflict)
 func ConflictHTTP(message string) error {
 	return newHTTPError(message, hTTPConflict)
 }
 
+// ConflictHTTPf создает ошибку с HTTP-статусом 409 (Conflict) с форматированным сообщением
+// ConflictHTTPf creates an error with HTTP status 409 (Conflict) with a formatted message
+func ConflictHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPConflict, format, args...)
+}
+
 // GoneHTTP создает ошибку с HTTP-статусом 410 (Gone)
 // GoneHTTP creates an error with HTTP status 410 (Gone)
 func GoneHTTP(message string) error {
 	return newHTTPError(message, hTTPGone)
 }
 
+// GoneHTTPf создает ошибку с HTTP-статусом 410 (Gone) с форматированным сообщением
+// GoneHTTPf creates an error with HTTP status 410 (Gone) with a formatted message
+func GoneHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPGone, format, args...)
+}
+
 // LengthRequiredHTTP создает ошибку с HTTP-статусом 411 (Length Required)
 // LengthRequiredHTTP creates an error with HTTP status 411 (Length Required)
 func LengthRequiredHTTP(message string) error {
 	return newHTTPError(message, hTTPStatusLengthRequired)
 }
 
+// LengthRequiredHTTPf создает ошибку с HTTP-статусом 411 (Length Required) с форматированным сообщением
+// LengthRequiredHTTPf creates an error with HTTP status 411 (Length Required) with a formatted message
+func LengthRequiredHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPStatusLengthRequired, format, args...)
+}
+
 // PreconditionFailedHTTP создает ошибку с HTTP-статусом 412 (Precondition Failed)
 // PreconditionFailedHTTP creates an error with HTTP status 412 (Precondition Failed)
 func PreconditionFailedHTTP(message string) error {
 	return newHTTPError(message, hTTPStatusPreconditionFailed)
 }
 
+// PreconditionFailedHTTPf создает ошибку с HTTP-статусом 412 (Precondition Failed) с форматированным сообщением
+// PreconditionFailedHTTPf creates an error with HTTP status 412 (Precondition Failed) with a formatted message
+func PreconditionFailedHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPStatusPreconditionFailed, format, args...)
+}
+
 // PayloadTooLargeHTTP создает ошибку с HTTP-статусом 413 (Payload Too Large)
 // PayloadTooLargeHTTP creates an error with HTTP status 413 (Payload Too Large)
 func PayloadTooLargeHTTP(message string) error {
 	return newHTTPError(message, hTTPPayloadTooLarge)
 }
 
+// PayloadTooLargeHTTPf создает ошибку с HTTP-статусом 413 (Payload Too Large) с форматированным сообщением
+// PayloadTooLargeHTTPf creates an error with HTTP status 413 (Payload Too Large) with a formatted message
+func PayloadTooLargeHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPPayloadTooLarge, format, args...)
+}
+
 // URITooLongHTTP создает ошибку с HTTP-статусом 414 (URI Too Long)
 // URITooLongHTTP creates an error with HTTP status 414 (URI Too Long)
 func URITooLongHTTP(message string) error {
 	return newHTTPError(message, hTTPURITooLong)
 }
 
+// URITooLongHTTPf создает ошибку с HTTP-статусом 414 (URI Too Long) с форматированным сообщением
+// URITooLongHTTPf creates an error with HTTP status 414 (URI Too Long) with a formatted message
+func URITooLongHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPURITooLong, format, args...)
+}
+
 // UnsupportedMediaTypeHTTP создает ошибку с HTTP-статусом 415 (Unsupported Media Type)
 // UnsupportedMediaTypeHTTP creates an error with HTTP status 415 (Unsupported Media Type)
 func UnsupportedMediaTypeHTTP(message string) error {
 	return newHTTPError(message, hTTPUnsupportedMediaType)
 }
 
+// UnsupportedMediaTypeHTTPf создает ошибку с HTTP-статусом 415 (Unsupported Media Type) с форматированным сообщением
+// UnsupportedMediaTypeHTTPf creates an error with HTTP status 415 (Unsupported Media Type) with a formatted message
+func UnsupportedMediaTypeHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPUnsupportedMediaType, format, args...)
+}
+
 // RangeNotSatisfiableHTTP создает ошибку с HTTP-статусом 416 (Range Not Satisfiable)
 // RangeNotSatisfiableHTTP creates an error with HTTP status 416 (Range Not Satisfiable)
 func RangeNotSatisfiableHTTP(message string) error {
 	return newHTTPError(message, hTTPStatusRequestedRangeNotSatisfiable)
 }
 
+// RangeNotSatisfiableHTTPf создает ошибку с HTTP-статусом 416 (Range Not Satisfiable) с форматированным сообщением
+// RangeNotSatisfiableHTTPf creates an error with HTTP status 416 (Range Not Satisfiable) with a formatted message
+func RangeNotSatisfiableHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPStatusRequestedRangeNotSatisfiable, format, args...)
+}
+
 // ExpectationFailedHTTP создает ошибку с HTTP-статусом 417 (Expectation Failed)
 // ExpectationFailedHTTP creates an error with HTTP status 417 (Expectation Failed)
 func ExpectationFailedHTTP(message string) error {
 	return newHTTPError(message, hTTPStatusExpectationFailed)
 }
 
+// ExpectationFailedHTTPf создает ошибку с HTTP-статусом 417 (Expectation Failed) с форматированным сообщением
+// ExpectationFailedHTTPf creates an error with HTTP status 417 (Expectation Failed) with a formatted message
+func ExpectationFailedHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPStatusExpectationFailed, format, args...)
+}
+
 // TeapotHTTP создает ошибку с HTTP-статусом 418 (I'm a teapot)
 // TeapotHTTP creates an error with HTTP status 418 (I'm a teapot)
 func TeapotHTTP(message string) error {
 	return newHTTPError(message, hTTPStatusTeapot)
 }
 
+// TeapotHTTPf создает ошибку с HTTP-статусом 418 (I'm a teapot) с форматированным сообщением
+// TeapotHTTPf creates an error with HTTP status 418 (I'm a teapot) with a formatted message
+func TeapotHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPStatusTeapot, format, args...)
+}
+
 // UnprocessableEntityHTTP создает ошибку с HTTP-статусом 422 (Unprocessable Entity)
 // UnprocessableEntityHTTP creates an error with HTTP status 422 (Unprocessable Entity)
 func UnprocessableEntityHTTP(message string) error {
 	return newHTTPError(message, hTTPUnprocessableEntity)
 }
 
+// UnprocessableEntityHTTPf создает ошибку с HTTP-статусом 422 (Unprocessable Entity) с форматированным сообщением
+// UnprocessableEntityHTTPf creates an error with HTTP status 422 (Unprocessable Entity) with a formatted message
+func UnprocessableEntityHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPUnprocessableEntity, format, args...)
+}
+
 // TooManyRequestsHTTP создает ошибку с HTTP-статусом 429 (Too Many Requests)
 // TooManyRequestsHTTP creates an error with HTTP status 429 (Too Many Requests)
 func TooManyRequestsHTTP(message string) error {
 	return newHTTPError(message, hTTPTooManyRequests)
 }
 
+// TooManyRequestsHTTPf создает ошибку с HTTP-статусом 429 (Too Many Requests) с форматированным сообщением
+// TooManyRequestsHTTPf creates an error with HTTP status 429 (Too Many Requests) with a formatted message
+func TooManyRequestsHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPTooManyRequests, format, args...)
+}
+
 // InternalServerHTTP создает ошибку с HTTP-статусом 500 (Internal Server Error)
 // InternalServerHTTP creates an error with HTTP status 500 (Internal Server Error)
 func InternalServerHTTP(message string) error {
 	return newHTTPError(message, hTTPInternalServerError)
 }
 
+// InternalServerHTTPf создает ошибку с HTTP-статусом 500 (Internal Server Error) с форматированным сообщением
+// InternalServerHTTPf creates an error with HTTP status 500 (Internal Server Error) with a formatted message
+func InternalServerHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPInternalServerError, format, args...)
+}
+
 // NotImplementedHTTP создает ошибку с HTTP-статусом 501 (Not Implemented)
 // NotImplementedHTTP creates an error with HTTP status 501 (Not Implemented)
 func NotImplementedHTTP(message string) error {
 	return newHTTPError(message, hTTPNotImplemented)
 }
 
+// NotImplementedHTTPf создает ошибку с HTTP-статусом 501 (Not Implemented) с форматированным сообщением
+// NotImplementedHTTPf creates an error with HTTP status 501 (Not Implemented) with a formatted message
+func NotImplementedHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPNotImplemented, format, args...)
+}
+
 // BadGatewayHTTP создает ошибку с HTTP-статусом 502 (Bad Gateway)
 // BadGatewayHTTP creates an error with HTTP status 502 (Bad Gateway)
 func BadGatewayHTTP(message string) error {
 	return newHTTPError(message, hTTPBadGateway)
 }
 
+// BadGatewayHTTPf создает ошибку с HTTP-статусом 502 (Bad Gateway) с форматированным сообщением
+// BadGatewayHTTPf creates an error with HTTP status 502 (Bad Gateway) with a formatted message
+func BadGatewayHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPBadGateway, format, args...)
+}
+
 // ServiceUnavailableHTTP создает ошибку с HTTP-статусом 503 (Service Unavailable)
 // ServiceUnavailableHTTP creates an error with HTTP status 503 (Service Unavailable)
 func ServiceUnavailableHTTP(message string) error {
 	return newHTTPError(message, hTTPServiceUnavailable)
 }
 
+// ServiceUnavailableHTTPf создает ошибку с HTTP-статусом 503 (Service Unavailable) с форматированным сообщением
+// ServiceUnavailableHTTPf creates an error with HTTP status 503 (Service Unavailable) with a formatted message
+func ServiceUnavailableHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPServiceUnavailable, format, args...)
+}
+
 // GatewayTimeoutHTTP создает ошибку с HTTP-статусом 504 (Gateway Timeout)
 // GatewayTimeoutHTTP creates an error with HTTP status 504 (Gateway Timeout)
 func GatewayTimeoutHTTP(message string) error {
 	return newHTTPError(message, hTTPGatewayTimeout)
 }
 
+// GatewayTimeoutHTTPf создает ошибку с HTTP-статусом 504 (Gateway Timeout) с форматированным сообщением
+// GatewayTimeoutHTTPf creates an error with HTTP status 504 (Gateway Timeout) with a formatted message
+func GatewayTimeoutHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPGatewayTimeout, format, args...)
+}
+
 // VersionNotSupportedHTTP создает ошибку с HTTP-статусом 505 (HTTP Version Not Supported)
 // VersionNotSupportedHTTP creates an error with HTTP status 505 (HTTP Version Not Supported)
 func VersionNotSupportedHTTP(message string) error {
 	return newHTTPError(message, hTTPStatusHTTPVersionNotSupported)
 }
+
+// VersionNotSupportedHTTPf создает ошибку с HTTP-статусом 505 (HTTP Version Not Supported) с форматированным сообщением
+// VersionNotSupportedHTTPf creates an error with HTTP status 505 (HTTP Version Not Supported) with a formatted message
+func VersionNotSupportedHTTPf(format string, args ...any) error {
+	return newHTTPErrorf(hTTPStatusHTTPVersionNotSupported, format, args...)
+}
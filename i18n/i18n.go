@@ -0,0 +1,148 @@
+// Package i18n локализует сообщения ошибок по их причине
+// Package i18n localizes error messages by their reason
+package i18n
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"text/template"
+
+	errs "github.com/eserg-key/errors"
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+)
+
+// AcceptLanguageKey - ключ метаданных gRPC с предпочитаемыми языками клиента
+// AcceptLanguageKey - gRPC metadata key with the client's preferred languages
+const AcceptLanguageKey = "accept-language"
+
+// TemplateData - данные, доступные в шаблоне сообщения
+// TemplateData - data available in a message template
+type TemplateData struct {
+	Message string
+	Reason  string
+}
+
+// Catalog хранит шаблоны сообщений по причине ошибки и языку
+// Catalog stores message templates by error reason and language
+type Catalog struct {
+	mu        sync.RWMutex
+	templates map[string]map[language.Tag]*template.Template
+}
+
+// NewCatalog создает пустой каталог
+// NewCatalog creates an empty catalog
+func NewCatalog() *Catalog {
+	return &Catalog{templates: make(map[string]map[language.Tag]*template.Template)}
+}
+
+// Add добавляет шаблон сообщения для причины и языка, например
+// Add("USER_NOT_FOUND", language.Russian, "Пользователь не найден")
+// Add adds a message template for the reason and language, e.g.
+// Add("USER_NOT_FOUND", language.English, "User not found")
+func (c *Catalog) Add(reason string, tag language.Tag, text string) error {
+	tmpl, err := template.New(reason).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.templates[reason] == nil {
+		c.templates[reason] = make(map[language.Tag]*template.Template)
+	}
+	c.templates[reason][tag] = tmpl
+	return nil
+}
+
+// lookup returns the template for the reason and language
+func (c *Catalog) lookup(reason string, tag language.Tag) *template.Template {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.templates[reason][tag]
+}
+
+// Localizer выбирает язык клиента и формирует локализованные сообщения
+// Localizer picks the client's language and renders localized messages
+type Localizer struct {
+	catalog *Catalog
+	matcher language.Matcher
+	tags    []language.Tag
+}
+
+// NewLocalizer создает Localizer для поддерживаемых языков.
+// Первый язык используется по умолчанию
+// NewLocalizer creates a Localizer for the supported languages.
+// The first language is the default one
+func NewLocalizer(catalog *Catalog, supported ...language.Tag) *Localizer {
+	return &Localizer{
+		catalog: catalog,
+		matcher: language.NewMatcher(supported),
+		tags:    supported,
+	}
+}
+
+// Match выбирает наиболее подходящий поддерживаемый язык по значениям
+// заголовка Accept-Language
+// Match picks the best supported language from Accept-Language header values
+func (l *Localizer) Match(acceptLanguage ...string) language.Tag {
+	var desired []language.Tag
+	for _, value := range acceptLanguage {
+		tags, _, err := language.ParseAcceptLanguage(value)
+		if err != nil {
+			continue
+		}
+		desired = append(desired, tags...)
+	}
+
+	_, index, _ := l.matcher.Match(desired...)
+	return l.tags[index]
+}
+
+// MatchContext выбирает язык по метаданным входящего gRPC-запроса
+// MatchContext picks the language from the incoming gRPC request metadata
+func (l *Localizer) MatchContext(ctx context.Context) language.Tag {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return l.Match(md.Get(AcceptLanguageKey)...)
+}
+
+// Localize возвращает сообщение ошибки на указанном языке. Если в каталоге нет
+// шаблона для причины ошибки, возвращается errors.PublicMessage. Поле Message
+// шаблона также заполняется errors.PublicMessage
+// Localize returns the error message in the given language. If the catalog has
+// no template for the error's reason, errors.PublicMessage is returned. The Message
+// field of the template is also filled with errors.PublicMessage
+func (l *Localizer) Localize(err error, tag language.Tag) string {
+	if err == nil {
+		return ""
+	}
+
+	message, reason := errs.PublicMessage(err), errs.Reason(err)
+	tmpl := l.catalog.lookup(reason, tag)
+	if tmpl == nil {
+		return message
+	}
+
+	var builder strings.Builder
+	if tmpl.Execute(&builder, TemplateData{Message: message, Reason: reason}) != nil {
+		return message
+	}
+	return builder.String()
+}
+
+// LocalizedMessage возвращает errdetails.LocalizedMessage для деталей gRPC-статуса
+// LocalizedMessage returns errdetails.LocalizedMessage for gRPC status details
+func (l *Localizer) LocalizedMessage(err error, tag language.Tag) *errdetails.LocalizedMessage {
+	if err == nil {
+		return nil
+	}
+
+	return &errdetails.LocalizedMessage{
+		Locale:  tag.String(),
+		Message: l.Localize(err, tag),
+	}
+}
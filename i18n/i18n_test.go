@@ -0,0 +1,89 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/metadata"
+)
+
+func newLocalizer(t *testing.T) *Localizer {
+	catalog := NewCatalog()
+	if err := catalog.Add("USER_NOT_FOUND", language.English, "User not found"); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.Add("USER_NOT_FOUND", language.Russian, "Пользователь не найден: {{.Message}}"); err != nil {
+		t.Fatal(err)
+	}
+	return NewLocalizer(catalog, language.English, language.Russian)
+}
+
+func TestMatch(t *testing.T) {
+	l := newLocalizer(t)
+
+	tests := []struct {
+		name     string
+		header   string
+		expected language.Tag
+	}{
+		{"Russian", "ru-RU,ru;q=0.9,en;q=0.8", language.Russian},
+		{"English", "en-US", language.English},
+		{"Quality Order", "en;q=0.5,ru;q=0.9", language.Russian},
+		{"Unsupported", "de-DE", language.English},
+		{"Empty", "", language.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tag := l.Match(tt.header); tag != tt.expected {
+				t.Errorf("Expected language %s, got %s", tt.expected, tag)
+			}
+		})
+	}
+}
+
+func TestMatchContext(t *testing.T) {
+	l := newLocalizer(t)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(AcceptLanguageKey, "ru"))
+	if tag := l.MatchContext(ctx); tag != language.Russian {
+		t.Errorf("Expected language ru, got %s", tag)
+	}
+	if tag := l.MatchContext(context.Background()); tag != language.English {
+		t.Errorf("Expected language en, got %s", tag)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	l := newLocalizer(t)
+	err := errs.WithReason(errs.NotFoundHTTP("id=42"), "USER_NOT_FOUND")
+
+	if msg := l.Localize(err, language.Russian); msg != "Пользователь не найден: id=42" {
+		t.Errorf("Unexpected Russian message '%s'", msg)
+	}
+	if msg := l.Localize(err, language.English); msg != "User not found" {
+		t.Errorf("Unexpected English message '%s'", msg)
+	}
+	if msg := l.Localize(errs.NotFoundHTTP("Not found"), language.Russian); msg != "Not found" {
+		t.Errorf("Expected fallback to the original message, got '%s'", msg)
+	}
+
+	detail := l.LocalizedMessage(err, language.Russian)
+	if detail.GetLocale() != "ru" || detail.GetMessage() != "Пользователь не найден: id=42" {
+		t.Errorf("Unexpected localized message %v", detail)
+	}
+
+	sensitive := errs.WithReason(errs.NotFoundHTTP("bob@example.com"), "USER_NOT_FOUND")
+	if msg := l.Localize(sensitive, language.Russian); msg != "Пользователь не найден: "+errs.RedactedPlaceholder {
+		t.Errorf("Expected the template message to be redacted, got '%s'", msg)
+	}
+	if msg := l.Localize(errs.NotFoundHTTP("bob@example.com"), language.Russian); msg != errs.RedactedPlaceholder {
+		t.Errorf("Expected the fallback message to be redacted, got '%s'", msg)
+	}
+	public := errs.New(errs.ProtocolHTTP, 404, "user bob missing", errs.PublicMessageOption("User missing"))
+	if msg := l.LocalizedMessage(public, language.Russian).GetMessage(); msg != "User missing" {
+		t.Errorf("Expected the public message as fallback, got '%s'", msg)
+	}
+}
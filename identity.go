@@ -0,0 +1,181 @@
+package errors
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Metadata keys set by ErrorInfo
+const (
+	InstanceIDKey  = "instance_id"
+	FingerprintKey = "fingerprint"
+)
+
+// crockford - алфавит Crockford base32, используемый в ULID
+// crockford - Crockford base32 alphabet used by ULID
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// idGenerator holds the function generating instance IDs
+var idGenerator atomic.Value
+
+// modulePath is the import path of the module, e.g. github.com/eserg-key/errors
+var modulePath = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndex(name, "/")
+	return name[:slash+strings.Index(name[slash:], ".")]
+}()
+
+// SetInstanceIDGenerator заменяет генератор идентификаторов экземпляров ошибок.
+// nil восстанавливает генератор ULID по умолчанию
+// SetInstanceIDGenerator replaces the generator of error instance IDs.
+// nil restores the default ULID generator
+func SetInstanceIDGenerator(generator func() string) {
+	if generator == nil {
+		generator = newULID
+	}
+	idGenerator.Store(generator)
+}
+
+// InstanceID возвращает уникальный идентификатор экземпляра ошибки
+// InstanceID returns the unique ID of the error instance
+func InstanceID(err error) string {
+	var er *Error
+	if errors.As(err, &er) {
+		return er.id
+	}
+	return ""
+}
+
+// Fingerprint возвращает стабильный отпечаток ошибки: хеш причины, gRPC кода
+// и функции, в которой ошибка создана. Отпечаток не зависит от текста ошибки.
+// У ошибок без места создания, например ошибок других типов, вместо него
+// используются тип и текст ошибки, чтобы разные ошибки не совпадали
+// Fingerprint returns the stable fingerprint of the error: a hash of the reason,
+// the gRPC code and the function the error was created in. It does not depend
+// on the error text. Errors without an origin, e.g. errors of other types, use
+// their type and text instead, so unrelated errors do not collide
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var origin string
+	var er *Error
+	if errors.As(err, &er) {
+		origin = er.origin
+	}
+	if origin == "" {
+		origin = fmt.Sprintf("%T\n%s", err, err.Error())
+	}
+
+	sum := sha1.Sum([]byte(Reason(err) + "\n" + CodeName(err) + "\n" + origin))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ErrorInfo возвращает errdetails.ErrorInfo для деталей gRPC-статуса с причиной,
+// метаданными, идентификатором экземпляра и отпечатком ошибки. domain используется,
+// если домен не задан в ошибке через DomainOption
+// ErrorInfo returns errdetails.ErrorInfo for gRPC status details with the reason,
+// the metadata, the instance ID and the fingerprint of the error. domain is used
+// if the error has no domain set via DomainOption
+func ErrorInfo(err error, domain string) *errdetails.ErrorInfo {
+	if err == nil {
+		return nil
+	}
+
+	metadata := make(map[string]string, len(Metadata(err))+2)
+	for k, v := range PublicMetadata(err) {
+		metadata[k] = v
+	}
+	if id := InstanceID(err); id != "" {
+		metadata[InstanceIDKey] = id
+	}
+	metadata[FingerprintKey] = Fingerprint(err)
+
+	if d := Domain(err); d != "" {
+		domain = d
+	}
+	return &errdetails.ErrorInfo{Reason: Reason(err), Domain: domain, Metadata: metadata}
+}
+
+// LogValue реализует slog.LogValuer: ошибка логируется с кодом, причиной,
+// идентификатором экземпляра и отпечатком
+// LogValue implements slog.LogValuer: the error is logged with its code, reason,
+// instance ID and fingerprint
+func (e *Error) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("message", redactor.Load().String(e.Message)),
+		slog.String("code", CodeName(e)),
+		slog.String(FingerprintKey, Fingerprint(e)),
+	}
+	if e.reason != "" {
+		attrs = append(attrs, slog.String("reason", e.reason))
+	}
+	if e.id != "" {
+		attrs = append(attrs, slog.String(InstanceIDKey, e.id))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// stamp присваивает ошибке идентификатор экземпляра и место создания
+// stamp assigns the instance ID and the origin to the error
+func (e *Error) stamp() *Error {
+	e.id = idGenerator.Load().(func() string)()
+	e.origin = callerOrigin()
+	return e
+}
+
+// callerOrigin returns the first function outside of the module and the runtime,
+// so errors created by subpackages such as sqlstate or mq get the origin of their caller
+func callerOrigin() string {
+	var pcs [16]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+
+	for {
+		frame, more := frames.Next()
+		internal := inModule(frame.Function) && !strings.HasSuffix(frame.File, "_test.go")
+		if !internal && !strings.HasPrefix(frame.Function, "runtime.") {
+			return frame.Function
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// inModule reports whether the function belongs to a package of the module
+func inModule(function string) bool {
+	rest, ok := strings.CutPrefix(function, modulePath)
+	return ok && (strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "/"))
+}
+
+// newULID returns a ULID: 48 bits of milliseconds and 80 random bits in Crockford base32
+func newULID() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	_, _ = rand.Read(b[6:])
+
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var id [26]byte
+	for i := len(id) - 1; i >= 0; i-- {
+		id[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(id[:])
+}
+
+func init() {
+	SetInstanceIDGenerator(nil)
+}
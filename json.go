@@ -0,0 +1,205 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// jsonSchemaVersion - версия схемы JSON-представления ошибки
+// jsonSchemaVersion - version of the JSON representation of an error
+const jsonSchemaVersion = 1
+
+// jsonError - JSON-представление ошибки. Версия указывается только на верхнем уровне
+// jsonError - JSON representation of an error. The version is set on the top level only
+type jsonError struct {
+	Version  int               `json:"version,omitempty"`
+	Message  string            `json:"message"`
+	Protocol ProtocolType      `json:"protocol,omitempty"`
+	Code     Code              `json:"code"`
+	Reason   string            `json:"reason,omitempty"`
+	Domain   string            `json:"domain,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Details  []json.RawMessage `json:"details,omitempty"`
+	ID       string            `json:"instance_id,omitempty"`
+	Origin   string            `json:"origin,omitempty"`
+	Cause    *jsonError        `json:"cause,omitempty"`
+	Causes   []*jsonError      `json:"causes,omitempty"`
+}
+
+// MarshalJSON кодирует ошибку в JSON вместе с кодом, протоколом и цепочкой причин
+// MarshalJSON encodes the error to JSON together with its code, protocol and cause chain.
+// Messages and metadata are redacted by the Redactor set via SetRedactor
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return marshalJSON(e, redactor.Load())
+}
+
+// UnmarshalJSON восстанавливает ошибку из JSON, созданного MarshalJSON. Коды, недопустимые
+// для протокола, отклоняются с ошибкой, удовлетворяющей errors.Is(err, ErrInvalidCode)
+// UnmarshalJSON restores the error from JSON produced by MarshalJSON. Codes invalid
+// for the protocol are rejected with an error satisfying errors.Is(err, ErrInvalidCode)
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var je jsonError
+	if err := json.Unmarshal(data, &je); err != nil {
+		return err
+	}
+
+	if je.Version != jsonSchemaVersion {
+		return fmt.Errorf("errors: unsupported JSON schema version %d", je.Version)
+	}
+
+	er, err := je.toError()
+	if err != nil {
+		return err
+	}
+
+	*e = *er
+	return nil
+}
+
+// marshalJSON encodes the error to JSON redacting it with r
+func marshalJSON(e *Error, r *Redactor) ([]byte, error) {
+	je, err := newJSONError(e, r)
+	if err != nil {
+		return nil, err
+	}
+	je.Version = jsonSchemaVersion
+	return json.Marshal(je)
+}
+
+// newJSONError converts the error and its causes into the JSON representation.
+// A single cause is encoded in cause, several causes (Wrapf with %w, errors.Join) in causes.
+// Details are encoded as google.protobuf.Any. Causes of other types keep only their message
+func newJSONError(err error, r *Redactor) (*jsonError, error) {
+	je := &jsonError{Message: r.String(err.Error())}
+	if er, ok := err.(*Error); ok {
+		je.Message = r.String(er.Message)
+		je.Protocol = er.typeProtocol
+		je.Code = er.code
+		je.Reason = er.reason
+		je.Domain = er.domain
+		je.Metadata = r.Metadata(er.metadata)
+		je.ID = er.id
+		je.Origin = er.origin
+
+		for _, detail := range er.details {
+			data, err := marshalDetail(detail)
+			if err != nil {
+				return nil, err
+			}
+			je.Details = append(je.Details, data)
+		}
+	}
+
+	for _, cause := range unwrapAll(err) {
+		jc, err := newJSONError(cause, r)
+		if err != nil {
+			return nil, err
+		}
+		je.Causes = append(je.Causes, jc)
+	}
+	if len(je.Causes) == 1 {
+		je.Cause, je.Causes = je.Causes[0], nil
+	}
+	return je, nil
+}
+
+// toError converts the JSON representation into an error
+func (je *jsonError) toError() (*Error, error) {
+	switch je.Protocol {
+	case httpProtocol, grpcProtocol:
+		if !validCode(je.Protocol, je.Code) {
+			return nil, fmt.Errorf("%w: %d for %q", ErrInvalidCode, je.Code, je.Protocol)
+		}
+	case "":
+		// Causes of other types carry only their message
+		if je.Code != 0 {
+			return nil, fmt.Errorf("%w: %d without protocol", ErrInvalidCode, je.Code)
+		}
+	default:
+		return nil, fmt.Errorf("errors: unknown protocol %q", je.Protocol)
+	}
+
+	er := &Error{
+		Message:      je.Message,
+		code:         je.Code,
+		typeProtocol: je.Protocol,
+		reason:       je.Reason,
+		domain:       je.Domain,
+		metadata:     je.Metadata,
+		id:           je.ID,
+		origin:       je.Origin,
+	}
+	for _, data := range je.Details {
+		detail, err := unmarshalDetail(data)
+		if err != nil {
+			return nil, err
+		}
+		er.details = append(er.details, detail)
+	}
+
+	cause, err := je.toCauses()
+	if err != nil {
+		return nil, err
+	}
+	er.cause = cause
+	return er, nil
+}
+
+// toCause converts the JSON representation of a cause into an error.
+// Causes of other types carry only their message and are restored as plainError
+func (je *jsonError) toCause() (error, error) {
+	if je.Protocol != "" {
+		er, err := je.toError()
+		if err != nil {
+			return nil, err
+		}
+		return er, nil
+	}
+	if je.Code != 0 {
+		return nil, fmt.Errorf("%w: %d without protocol", ErrInvalidCode, je.Code)
+	}
+
+	cause, err := je.toCauses()
+	if err != nil {
+		return nil, err
+	}
+	return &plainError{message: je.Message, cause: cause}, nil
+}
+
+// toCauses converts the cause or causes fields into the cause of the error
+func (je *jsonError) toCauses() (error, error) {
+	var list []error
+	for _, jc := range append([]*jsonError{je.Cause}, je.Causes...) {
+		if jc == nil {
+			continue
+		}
+		cause, err := jc.toCause()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, cause)
+	}
+	return joinCauses(list), nil
+}
+
+// marshalDetail encodes the detail as JSON of google.protobuf.Any
+func marshalDetail(detail proto.Message) (json.RawMessage, error) {
+	packed, err := anypb.New(detail)
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(packed)
+}
+
+// unmarshalDetail decodes the detail from JSON of google.protobuf.Any.
+// The detail type must be linked into the binary
+func unmarshalDetail(data json.RawMessage) (proto.Message, error) {
+	var packed anypb.Any
+	if err := protojson.Unmarshal(data, &packed); err != nil {
+		return nil, err
+	}
+	return packed.UnmarshalNew()
+}
@@ -0,0 +1,106 @@
+// Package jsonrpc преобразует ошибки в объекты ошибок JSON-RPC 2.0 и обратно
+// Package jsonrpc converts errors into JSON-RPC 2.0 error objects and back
+package jsonrpc
+
+import (
+	errs "github.com/eserg-key/errors"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	ParseError     = -32700
+	InvalidRequest = -32600
+	MethodNotFound = -32601
+	InvalidParams  = -32602
+	InternalError  = -32603
+
+	// Коды ошибок сервера -32000..-32099. Код -32000-N соответствует gRPC коду N
+	// Server error codes -32000..-32099. Code -32000-N corresponds to gRPC code N
+	ServerErrorMax = -32000
+	ServerErrorMin = -32099
+)
+
+// Error - объект ошибки JSON-RPC 2.0
+// Error - JSON-RPC 2.0 error object
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    *Data  `json:"data,omitempty"`
+}
+
+// Data - дополнительные сведения об ошибке в поле data
+// Data - additional error information in the data field
+type Data struct {
+	Reason   string            `json:"reason,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Error возвращает текстовое представление ошибки
+// Error returns a text representation of the error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Encode преобразует ошибку в объект ошибки JSON-RPC по её gRPC коду.
+// InvalidArgument, Unimplemented и серверные ошибки получают стандартные коды,
+// остальные - коды из диапазона ошибок сервера
+// Encode converts the error into a JSON-RPC error object by its gRPC code.
+// InvalidArgument, Unimplemented and server faults get the standard codes,
+// the rest get codes from the server error range
+func Encode(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	e := &Error{Code: codeFromGRPC(errs.StatusGRPC(err)), Message: errs.PublicMessage(err)}
+
+	reason, metadata := errs.Reason(err), errs.PublicMetadata(err)
+	if reason != "" || len(metadata) > 0 {
+		e.Data = &Data{Reason: reason, Metadata: metadata}
+	}
+	return e
+}
+
+// Decode преобразует объект ошибки JSON-RPC в ошибку с gRPC кодом
+// Decode converts a JSON-RPC error object into an error with a gRPC code
+func Decode(e *Error) error {
+	if e == nil {
+		return nil
+	}
+
+	var opts []errs.Option
+	if e.Data != nil {
+		opts = append(opts, errs.ReasonOption(e.Data.Reason), errs.MetadataOption(e.Data.Metadata))
+	}
+	return errs.New(errs.ProtocolGRPC, codeToGRPC(e.Code), e.Message, opts...)
+}
+
+// codeFromGRPC converts the gRPC code into a JSON-RPC error code
+func codeFromGRPC(code errs.Code) int {
+	switch code {
+	case errs.GRPCInvalidArgument:
+		return InvalidParams
+	case errs.GRPCUnimplemented:
+		return MethodNotFound
+	case errs.GRPCUnknown, errs.GRPCInternal, errs.GRPCDataLoss:
+		return InternalError
+	default:
+		return ServerErrorMax - int(code)
+	}
+}
+
+// codeToGRPC converts the JSON-RPC error code into a gRPC code
+func codeToGRPC(code int) errs.Code {
+	switch {
+	case code == ParseError, code == InvalidRequest, code == InvalidParams:
+		return errs.GRPCInvalidArgument
+	case code == MethodNotFound:
+		return errs.GRPCUnimplemented
+	case code <= ServerErrorMax-int(errs.GRPCCanceled) && code >= ServerErrorMax-int(errs.GRPCUnauthenticated):
+		return errs.Code(ServerErrorMax - code)
+	case code <= ServerErrorMax && code >= ServerErrorMin:
+		return errs.GRPCUnknown
+	default:
+		return errs.GRPCInternal
+	}
+}
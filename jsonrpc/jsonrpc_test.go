@@ -0,0 +1,86 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"Invalid Argument", errs.BadRequestHTTP("Bad request"), InvalidParams},
+		{"Unimplemented", errs.UnimplementedGRPC("No such method"), MethodNotFound},
+		{"Internal", errs.InternalServerHTTP("Internal"), InternalError},
+		{"Not Found", errs.NotFoundHTTP("Not found"), -32005},
+		{"Unauthenticated", errs.UnauthenticatedGRPC("Login required"), -32016},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := Encode(tt.err).Code; code != tt.expected {
+				t.Errorf("Expected JSON-RPC code %d, got %d", tt.expected, code)
+			}
+		})
+	}
+
+	if Encode(nil) != nil {
+		t.Error("Expected nil for nil error")
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     int
+		expected errs.Code
+	}{
+		{"Parse Error", ParseError, 3},
+		{"Invalid Request", InvalidRequest, 3},
+		{"Method Not Found", MethodNotFound, 12},
+		{"Invalid Params", InvalidParams, 3},
+		{"Internal Error", InternalError, 13},
+		{"Domain Code", -32006, 6},
+		{"Generic Server Error", -32050, 2},
+		{"Application Code", 42, 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := errs.StatusGRPC(Decode(&Error{Code: tt.code, Message: "m"})); code != tt.expected {
+				t.Errorf("Expected gRPC code %d, got %d", tt.expected, code)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := errs.New(errs.ProtocolHTTP, 409, "Email taken",
+		errs.ReasonOption("EMAIL_TAKEN"),
+		errs.MetadataOption(map[string]string{"field": "email"}),
+	)
+
+	data, err := json.Marshal(Encode(original))
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	expected := `{"code":-32010,"message":"Email taken","data":{"reason":"EMAIL_TAKEN","metadata":{"field":"email"}}}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON '%s', got '%s'", expected, data)
+	}
+
+	var e Error
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+
+	decoded := Decode(&e)
+	if errs.StatusHTTP(decoded) != 409 || errs.Reason(decoded) != "EMAIL_TAKEN" || errs.Metadata(decoded)["field"] != "email" {
+		t.Errorf("Unexpected decoded error %v", decoded)
+	}
+}
@@ -0,0 +1,102 @@
+// Package metrics считает ошибки в Prometheus с метками протокола, кода и причины
+// Package metrics counts errors in Prometheus labeled by protocol, code and reason
+package metrics
+
+import (
+	"strconv"
+	"sync"
+
+	errs "github.com/eserg-key/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// OtherReason заменяет причины сверх лимита
+// OtherReason replaces reasons beyond the limit
+const OtherReason = "other"
+
+// DefaultMaxReasons - лимит различных причин по умолчанию
+// DefaultMaxReasons - default limit of distinct reasons
+const DefaultMaxReasons = 100
+
+// Collector считает ошибки. Количество различных значений метки reason
+// ограничено, чтобы не допустить роста кардинальности
+// Collector counts errors. The number of distinct reason label values
+// is limited to prevent cardinality growth
+type Collector struct {
+	errors     *prometheus.CounterVec
+	maxReasons int
+
+	mu      sync.Mutex
+	reasons map[string]struct{}
+}
+
+// NewCollector создает Collector с метрикой <namespace>_errors_total.
+// maxReasons <= 0 означает DefaultMaxReasons
+// NewCollector creates a Collector with the <namespace>_errors_total metric.
+// maxReasons <= 0 means DefaultMaxReasons
+func NewCollector(namespace string, maxReasons int) *Collector {
+	if maxReasons <= 0 {
+		maxReasons = DefaultMaxReasons
+	}
+
+	return &Collector{
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Number of errors by protocol, code, reason and route.",
+		}, []string{"protocol", "code", "reason", "route"}),
+		maxReasons: maxReasons,
+		reasons:    make(map[string]struct{}),
+	}
+}
+
+// Describe реализует prometheus.Collector
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.errors.Describe(ch)
+}
+
+// Collect реализует prometheus.Collector
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.errors.Collect(ch)
+}
+
+// ObserveHTTP учитывает ошибку HTTP-обработчика с кодом StatusHTTP
+// ObserveHTTP counts an HTTP handler error with the StatusHTTP code
+func (c *Collector) ObserveHTTP(err error, route string) {
+	if err == nil {
+		return
+	}
+	c.errors.WithLabelValues("http", strconv.Itoa(errs.StatusHTTP(err)), c.reason(err), route).Inc()
+}
+
+// ObserveGRPC учитывает ошибку gRPC-метода с кодом StatusGRPC
+// ObserveGRPC counts a gRPC method error with the StatusGRPC code
+func (c *Collector) ObserveGRPC(err error, method string) {
+	if err == nil {
+		return
+	}
+	c.errors.WithLabelValues("grpc", errs.CodeName(err), c.reason(err), method).Inc()
+}
+
+// reason returns the reason label value, replacing new reasons
+// with OtherReason once the limit is reached
+func (c *Collector) reason(err error) string {
+	reason := errs.Reason(err)
+	if reason == "" {
+		return reason
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.reasons[reason]; ok {
+		return reason
+	}
+	if len(c.reasons) >= c.maxReasons {
+		return OtherReason
+	}
+	c.reasons[reason] = struct{}{}
+	return reason
+}
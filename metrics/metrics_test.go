@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector("app", 1)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collector)
+
+	collector.ObserveHTTP(errs.WithReason(errs.NotFoundHTTP("Not found"), "USER_NOT_FOUND"), "/users/{id}")
+	collector.ObserveHTTP(errs.WithReason(errs.NotFoundHTTP("Not found"), "USER_NOT_FOUND"), "/users/{id}")
+	collector.ObserveHTTP(errs.WithReason(errs.ConflictHTTP("Conflict"), "EMAIL_TAKEN"), "/users")
+	collector.ObserveGRPC(errs.UnavailableGRPC("Unavailable"), "/users.v1.Users/Get")
+	collector.ObserveGRPC(nil, "/users.v1.Users/Get")
+
+	expected := `
+# HELP app_errors_total Number of errors by protocol, code, reason and route.
+# TYPE app_errors_total counter
+app_errors_total{code="404",protocol="http",reason="USER_NOT_FOUND",route="/users/{id}"} 2
+app_errors_total{code="409",protocol="http",reason="other",route="/users"} 1
+app_errors_total{code="UNAVAILABLE",protocol="grpc",reason="",route="/users.v1.Users/Get"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected), "app_errors_total"); err != nil {
+		t.Error(err)
+	}
+}
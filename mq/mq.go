@@ -0,0 +1,184 @@
+// Package mq передает ошибки в заголовках сообщений брокеров очередей
+// (Kafka, AMQP, NATS) и решает, повторить обработку или отправить сообщение в DLQ
+// Package mq propagates errors in message headers of queue brokers
+// (Kafka, AMQP, NATS) and decides whether to retry or dead-letter a message
+package mq
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	errs "github.com/eserg-key/errors"
+)
+
+// Header keys
+const (
+	CodeHeader       = "x-error-code"
+	ProtocolHeader   = "x-error-protocol"
+	ReasonHeader     = "x-error-reason"
+	RetryableHeader  = "x-error-retryable"
+	RetryAfterHeader = "x-error-retry-after"
+	MessageHeader    = "x-error-message"
+	TraceIDHeader    = "x-error-trace-id"
+)
+
+// DefaultRetryAfter - интервал повтора восстановленной ошибки, помеченной как повторяемая,
+// если отправитель не передал RetryAfterHeader, а код ошибки сам по себе не повторяемый
+// DefaultRetryAfter - retry interval of a restored error marked as retryable when
+// the sender did not pass RetryAfterHeader and the error code alone is not retryable
+const DefaultRetryAfter = time.Second
+
+// TraceIDKey - ключ метаданных восстановленной ошибки с идентификатором трассировки
+// TraceIDKey - metadata key of the restored error with the trace ID
+const TraceIDKey = errs.TraceIDKey
+
+// Carrier - набор заголовков сообщения конкретного брокера
+// Carrier - message header set of a specific broker
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+}
+
+// MapCarrier - заголовки в виде map[string]string
+// MapCarrier - headers as map[string]string
+type MapCarrier map[string]string
+
+// Get реализует Carrier
+// Get implements Carrier
+func (c MapCarrier) Get(key string) string {
+	return c[key]
+}
+
+// Set реализует Carrier
+// Set implements Carrier
+func (c MapCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// BytesCarrier - заголовки в виде map[string][]byte, например заголовки Kafka
+// BytesCarrier - headers as map[string][]byte, e.g. Kafka headers
+type BytesCarrier map[string][]byte
+
+// Get реализует Carrier
+// Get implements Carrier
+func (c BytesCarrier) Get(key string) string {
+	return string(c[key])
+}
+
+// Set реализует Carrier
+// Set implements Carrier
+func (c BytesCarrier) Set(key, value string) {
+	c[key] = []byte(value)
+}
+
+// Inject записывает ошибку в заголовки. Идентификатор трассировки берется из метаданных
+// ошибки с ключом errors.TraceIDKey, а если его нет - из контекста через извлекатели,
+// зарегистрированные errors.RegisterContextExtractor (например, otel.ContextExtractor).
+// Заголовки видны всем потребителям очереди, поэтому сообщением становится errors.PublicMessage
+// Inject writes the error into the headers. The trace ID is taken from the error metadata
+// with the errors.TraceIDKey key or, if there is none, from the context via the extractors
+// registered with errors.RegisterContextExtractor (e.g. otel.ContextExtractor).
+// Headers are visible to every consumer of the queue, so the message is errors.PublicMessage
+func Inject(ctx context.Context, err error, c Carrier) {
+	if err == nil {
+		return
+	}
+
+	protocol := errs.Protocol(err)
+	code := int(errs.StatusGRPC(err))
+	if protocol == errs.ProtocolHTTP {
+		code = errs.StatusHTTP(err)
+	} else {
+		protocol = errs.ProtocolGRPC
+	}
+
+	c.Set(CodeHeader, strconv.Itoa(code))
+	c.Set(ProtocolHeader, string(protocol))
+	c.Set(RetryableHeader, strconv.FormatBool(errs.IsRetryable(err)))
+	if d, ok := errs.RetryAfter(err); ok {
+		c.Set(RetryAfterHeader, d.String())
+	}
+	c.Set(MessageHeader, errs.PublicMessage(err))
+	if reason := errs.Reason(err); reason != "" {
+		c.Set(ReasonHeader, reason)
+	}
+	if traceID := errs.Metadata(errs.WithContext(ctx, err))[TraceIDKey]; traceID != "" {
+		c.Set(TraceIDHeader, traceID)
+	}
+}
+
+// Extract восстанавливает ошибку из заголовков. Если заголовков ошибки нет,
+// возвращается nil. Признак повтора восстанавливается через RetryAfterOption
+// из RetryAfterHeader или DefaultRetryAfter
+// Extract restores the error from the headers. If there are no error headers,
+// it returns nil. The retry hint is restored via RetryAfterOption
+// from RetryAfterHeader or DefaultRetryAfter
+func Extract(c Carrier) error {
+	value := c.Get(CodeHeader)
+	if value == "" {
+		return nil
+	}
+
+	code, err := strconv.Atoi(value)
+	if err != nil {
+		code = int(errs.GRPCUnknown)
+	}
+
+	var opts []errs.Option
+	if reason := c.Get(ReasonHeader); reason != "" {
+		opts = append(opts, errs.ReasonOption(reason))
+	}
+	if traceID := c.Get(TraceIDHeader); traceID != "" {
+		opts = append(opts, errs.MetadataOption(map[string]string{TraceIDKey: traceID}))
+	}
+	if d, err := time.ParseDuration(c.Get(RetryAfterHeader)); err == nil && d > 0 {
+		opts = append(opts, errs.RetryAfterOption(d))
+	}
+
+	result := decode(c, code, opts)
+	// The sender marked the error as retryable, but its code alone is not
+	if retryable, _ := strconv.ParseBool(c.Get(RetryableHeader)); retryable && !errs.IsRetryable(result) {
+		result = decode(c, code, append(opts, errs.RetryAfterOption(DefaultRetryAfter)))
+	}
+	return result
+}
+
+// decode creates the error with the code and the protocol from the headers.
+// Invalid codes are decoded as Unknown
+func decode(c Carrier, code int, opts []errs.Option) error {
+	result := errs.New(errs.ProtocolType(c.Get(ProtocolHeader)), errs.Code(code), c.Get(MessageHeader), opts...)
+	if errors.Is(result, errs.ErrInvalidCode) {
+		return errs.New(errs.ProtocolGRPC, errs.GRPCUnknown, c.Get(MessageHeader), opts...)
+	}
+	return result
+}
+
+// Action - решение о дальнейшей судьбе сообщения
+// Action - decision on what to do with the message
+type Action int
+
+// Actions
+const (
+	Ack Action = iota
+	Retry
+	DeadLetter
+)
+
+// Decide решает, что делать с сообщением после попытки обработки attempt (с 1).
+// Ошибки, которые можно повторить, повторяются до maxAttempts попыток,
+// остальные сразу отправляются в DLQ
+// Decide decides what to do with the message after processing attempt (from 1).
+// Retryable errors are retried up to maxAttempts attempts, the rest are
+// dead-lettered immediately
+func Decide(err error, attempt, maxAttempts int) Action {
+	switch {
+	case err == nil:
+		return Ack
+	case errs.IsRetryable(err) && attempt < maxAttempts:
+		return Retry
+	default:
+		return DeadLetter
+	}
+}
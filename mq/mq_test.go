@@ -0,0 +1,158 @@
+package mq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "github.com/eserg-key/errors"
+)
+
+// traceIDKey is the context key of the trace ID set by the tests
+type traceIDKey struct{}
+
+func init() {
+	errs.RegisterContextExtractor(func(ctx context.Context) map[string]string {
+		if traceID, ok := ctx.Value(traceIDKey{}).(string); ok {
+			return map[string]string{TraceIDKey: traceID}
+		}
+		return nil
+	})
+}
+
+func TestInjectExtract(t *testing.T) {
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := context.WithValue(context.Background(), traceIDKey{}, traceID)
+
+	tests := []struct {
+		name    string
+		err     error
+		carrier Carrier
+	}{
+		{"HTTP Map Headers", errs.WithReason(errs.ConflictHTTP("Duplicate order"), "DUPLICATE"), MapCarrier{}},
+		{"gRPC Byte Headers", errs.UnavailableGRPC("Backend down"), BytesCarrier{}},
+		{"Plain Error", context.DeadlineExceeded, MapCarrier{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Inject(ctx, tt.err, tt.carrier)
+			decoded := Extract(tt.carrier)
+
+			if decoded.Error() != tt.err.Error() {
+				t.Errorf("Expected message '%s', got '%s'", tt.err.Error(), decoded.Error())
+			}
+			if errs.StatusHTTP(decoded) != errs.StatusHTTP(tt.err) || errs.StatusGRPC(decoded) != errs.StatusGRPC(tt.err) {
+				t.Errorf("Expected status %d, got %d", errs.StatusHTTP(tt.err), errs.StatusHTTP(decoded))
+			}
+			if errs.Reason(decoded) != errs.Reason(tt.err) {
+				t.Errorf("Expected reason '%s', got '%s'", errs.Reason(tt.err), errs.Reason(decoded))
+			}
+			if errs.IsRetryable(decoded) != errs.IsRetryable(tt.err) {
+				t.Error("Expected retryability to be preserved")
+			}
+			if errs.Metadata(decoded)[TraceIDKey] != traceID {
+				t.Errorf("Expected trace ID %s, got %s", traceID, errs.Metadata(decoded)[TraceIDKey])
+			}
+		})
+	}
+}
+
+func TestInjectErrorTraceID(t *testing.T) {
+	// A dead-lettered error re-published without an active span keeps its trace ID
+	carrier := MapCarrier{}
+	Inject(context.Background(), Extract(MapCarrier{CodeHeader: "5", ProtocolHeader: "grpc", TraceIDHeader: "trace-1"}), carrier)
+	if traceID := carrier.Get(TraceIDHeader); traceID != "trace-1" {
+		t.Errorf("Expected trace ID 'trace-1', got '%s'", traceID)
+	}
+
+	ctx := context.WithValue(context.Background(), traceIDKey{}, "trace-2")
+	carrier = MapCarrier{}
+	Inject(ctx, errs.New(errs.ProtocolGRPC, 5, "Missing", errs.MetadataOption(map[string]string{TraceIDKey: "trace-1"})), carrier)
+	if traceID := carrier.Get(TraceIDHeader); traceID != "trace-1" {
+		t.Errorf("Expected the trace ID of the error to win, got '%s'", traceID)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	if Extract(MapCarrier{}) != nil {
+		t.Error("Expected nil without error headers")
+	}
+
+	err := Extract(MapCarrier{CodeHeader: "999", ProtocolHeader: "smtp", MessageHeader: "Odd"})
+	if errs.StatusGRPC(err) != 2 || err.Error() != "Odd" {
+		t.Errorf("Expected invalid headers to decode as Unknown, got %v", err)
+	}
+}
+
+func TestInjectPublicMessage(t *testing.T) {
+	carrier := MapCarrier{}
+	Inject(context.Background(), errs.NotFoundHTTP("User bob@example.com not found"), carrier)
+	if message := carrier.Get(MessageHeader); message != "User "+errs.RedactedPlaceholder+" not found" {
+		t.Errorf("Expected the redacted message, got '%s'", message)
+	}
+}
+
+func TestExtractOrigin(t *testing.T) {
+	err := Extract(MapCarrier{CodeHeader: "5", ProtocolHeader: "grpc", MessageHeader: "nf"})
+	if origin := errs.ToProto(err).GetOrigin(); origin != "github.com/eserg-key/errors/mq.TestExtractOrigin" {
+		t.Errorf("Expected the caller of Extract as the origin, got %q", origin)
+	}
+}
+
+func TestRetryHintRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		retryAfter time.Duration
+	}{
+		{"Retry After", errs.New(errs.ProtocolGRPC, errs.GRPCNotFound, "nf", errs.RetryAfterOption(5*time.Second)), 5 * time.Second},
+		{"Retryable Header Only", nil, DefaultRetryAfter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carrier := MapCarrier{CodeHeader: "5", ProtocolHeader: "grpc", RetryableHeader: "true", MessageHeader: "nf"}
+			if tt.err != nil {
+				carrier = MapCarrier{}
+				Inject(context.Background(), tt.err, carrier)
+				if Decide(tt.err, 1, 3) != Retry {
+					t.Fatal("Expected the original error to be retried")
+				}
+			}
+
+			decoded := Extract(carrier)
+			if Decide(decoded, 1, 3) != Retry {
+				t.Error("Expected the extracted error to be retried")
+			}
+			if d, ok := errs.RetryAfter(decoded); !ok || d != tt.retryAfter {
+				t.Errorf("Expected retry after %s, got %s", tt.retryAfter, d)
+			}
+			if errs.StatusGRPC(decoded) != errs.GRPCNotFound {
+				t.Errorf("Expected gRPC code NotFound, got %d", errs.StatusGRPC(decoded))
+			}
+		})
+	}
+}
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		attempt  int
+		expected Action
+	}{
+		{"Success", nil, 1, Ack},
+		{"Retryable", errs.UnavailableGRPC("Down"), 1, Retry},
+		{"Retryable Exhausted", errs.UnavailableGRPC("Down"), 3, DeadLetter},
+		{"Not Retryable", errs.BadRequestHTTP("Bad payload"), 1, DeadLetter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if action := Decide(tt.err, tt.attempt, 3); action != tt.expected {
+				t.Errorf("Expected action %d, got %d", tt.expected, action)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"runtime/debug"
+	"time"
+)
+
+// ErrInvalidCode возвращается New, если код не подходит для протокола
+// ErrInvalidCode is returned by New if the code is not valid for the protocol
+var ErrInvalidCode = errors.New("errors: invalid code for protocol")
+
+// Option настраивает ошибку, создаваемую New
+// Option configures the error created by New
+type Option func(*Error)
+
+// New создает ошибку с кодом, заданным во время выполнения, например статусом
+// ответа вышестоящего сервиса. Для HTTP допустимы статусы 400-599, для gRPC - коды 1-16.
+// Для недопустимого кода возвращается ошибка, удовлетворяющая errors.Is(err, ErrInvalidCode)
+// New creates an error with a code known at run time, e.g. the response status
+// of an upstream service. HTTP accepts statuses 400-599, gRPC accepts codes 1-16.
+// For an invalid code an error satisfying errors.Is(err, ErrInvalidCode) is returned
+func New(protocol ProtocolType, code Code, message string, opts ...Option) error {
+	if !validCode(protocol, code) {
+		return fmt.Errorf("%w: %d for %q", ErrInvalidCode, code, protocol)
+	}
+
+	er := (&Error{Message: message, code: code, typeProtocol: protocol}).stamp()
+	for _, opt := range opts {
+		opt(er)
+	}
+	return er
+}
+
+// CauseOption задает причину ошибки
+// CauseOption sets the cause of the error
+func CauseOption(cause error) Option {
+	return func(e *Error) {
+		e.cause = cause
+	}
+}
+
+// ReasonOption задает машиночитаемую причину ошибки
+// ReasonOption sets the machine-readable reason of the error
+func ReasonOption(reason string) Option {
+	return func(e *Error) {
+		e.reason = reason
+	}
+}
+
+// DomainOption задает домен причины ошибки, например имя сервиса
+// DomainOption sets the domain of the error reason, e.g. the service name
+func DomainOption(domain string) Option {
+	return func(e *Error) {
+		e.domain = domain
+	}
+}
+
+// MetadataOption добавляет метаданные ошибки
+// MetadataOption adds metadata to the error
+func MetadataOption(metadata map[string]string) Option {
+	return func(e *Error) {
+		if e.metadata == nil {
+			e.metadata = make(map[string]string, len(metadata))
+		}
+		for k, v := range metadata {
+			e.metadata[k] = v
+		}
+	}
+}
+
+// DetailsOption добавляет детали ошибки, например сообщения errdetails
+// DetailsOption adds details to the error, e.g. errdetails messages
+func DetailsOption(details ...proto.Message) Option {
+	return func(e *Error) {
+		e.details = append(e.details, details...)
+	}
+}
+
+// RetryAfterOption задает интервал, после которого операцию можно повторить
+// RetryAfterOption sets the interval after which the operation can be retried
+func RetryAfterOption(d time.Duration) Option {
+	return func(e *Error) {
+		e.retryAfter = d
+	}
+}
+
+// PublicMessageOption задает сообщение, безопасное для показа клиенту
+// PublicMessageOption sets the message that is safe to show to the client
+func PublicMessageOption(message string) Option {
+	return func(e *Error) {
+		e.public = message
+	}
+}
+
+// SeverityOption задает уровень серьезности ошибки
+// SeverityOption sets the severity of the error
+func SeverityOption(severity Severity) Option {
+	return func(e *Error) {
+		e.severity = severity
+	}
+}
+
+// StackOption сохраняет в ошибке текущий стек вызовов
+// StackOption stores the current call stack in the error
+func StackOption() Option {
+	return func(e *Error) {
+		e.stack = debug.Stack()
+	}
+}
+
+// Metadata возвращает метаданные ошибки
+// Metadata returns the metadata of the error
+func Metadata(err error) map[string]string {
+	var er *Error
+	if errors.As(err, &er) {
+		return er.metadata
+	}
+	return nil
+}
+
+// Domain возвращает домен причины ошибки
+// Domain returns the domain of the error reason
+func Domain(err error) string {
+	var er *Error
+	if errors.As(err, &er) {
+		return er.domain
+	}
+	return ""
+}
+
+// Details возвращает детали ошибки
+// Details returns the details of the error
+func Details(err error) []proto.Message {
+	var er *Error
+	if errors.As(err, &er) {
+		return er.details
+	}
+	return nil
+}
+
+// RetryAfter возвращает интервал, после которого операцию можно повторить
+// RetryAfter returns the interval after which the operation can be retried
+func RetryAfter(err error) (time.Duration, bool) {
+	var er *Error
+	if errors.As(err, &er) && er.retryAfter > 0 {
+		return er.retryAfter, true
+	}
+	return 0, false
+}
+
+// PublicMessage возвращает сообщение для клиента: заданное через PublicMessageOption
+// или текст ошибки, обработанные Redactor
+// PublicMessage returns the message for the client: the one set via PublicMessageOption
+// or the error text, processed by the Redactor
+func PublicMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var er *Error
+	if errors.As(err, &er) && er.public != "" {
+		return redactor.Load().String(er.public)
+	}
+	return redactor.Load().String(err.Error())
+}
+
+// validCode reports whether the code is an error code of the protocol
+func validCode(protocol ProtocolType, code Code) bool {
+	switch protocol {
+	case httpProtocol:
+		return code >= hTTPBadRequest && code < 600
+	case grpcProtocol:
+		return code > gRPCOk && code <= gRPCUnauthenticated
+	default:
+		return false
+	}
+}
@@ -0,0 +1,87 @@
+// Package otel записывает ошибки в спаны OpenTelemetry
+// Package otel records errors on OpenTelemetry spans
+package otel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	errs "github.com/eserg-key/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys set on the span
+const (
+	HTTPStatusCodeKey = attribute.Key("http.response.status_code")
+	GRPCStatusCodeKey = attribute.Key("rpc.grpc.status_code")
+	ErrorTypeKey      = attribute.Key("error.type")
+)
+
+// Exception event of the OpenTelemetry semantic conventions
+const (
+	ExceptionEventName  = "exception"
+	ExceptionTypeKey    = attribute.Key("exception.type")
+	ExceptionMessageKey = attribute.Key("exception.message")
+)
+
+// RecordError записывает ошибку в активный спан контекста. Статус спана
+// устанавливается в Error только для ошибок сервера (5xx или серверные gRPC коды)
+// RecordError records the error on the active span of the context. The span
+// status is set to Error only for server faults (5xx or server-side gRPC codes)
+func RecordError(ctx context.Context, err error) {
+	RecordSpanError(trace.SpanFromContext(ctx), err)
+}
+
+// RecordSpanError записывает ошибку в указанный спан. Спан уходит во внешнюю систему
+// трассировки, поэтому сообщение в событии exception и в статусе спана - errors.PublicMessage
+// RecordSpanError records the error on the given span. The span goes to an external tracing
+// backend, so the message of the exception event and of the span status is errors.PublicMessage
+func RecordSpanError(span trace.Span, err error) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+
+	message := errs.PublicMessage(err)
+	span.AddEvent(ExceptionEventName, trace.WithAttributes(
+		ExceptionTypeKey.String(fmt.Sprintf("%T", err)),
+		ExceptionMessageKey.String(message),
+	))
+	span.SetAttributes(
+		HTTPStatusCodeKey.Int(errs.StatusHTTP(err)),
+		GRPCStatusCodeKey.Int(int(errs.StatusGRPC(err))),
+		ErrorTypeKey.String(errorType(err)),
+	)
+
+	// All gRPC codes treated as server faults by the OpenTelemetry
+	// semantic conventions map to 5xx
+	if errs.IsServerError(err) {
+		span.SetStatus(codes.Error, message)
+	}
+}
+
+// errorType returns the value of the error.type attribute
+func errorType(err error) string {
+	var er *errs.Error
+	if errors.As(err, &er) {
+		return errs.CodeName(err)
+	}
+	return fmt.Sprintf("%T", err)
+}
+
+// ContextExtractor извлекает идентификаторы трассировки и спана из контекста.
+// Регистрируется через errors.RegisterContextExtractor(otel.ContextExtractor)
+// ContextExtractor extracts the trace and span IDs from the context.
+// Registered via errors.RegisterContextExtractor(otel.ContextExtractor)
+func ContextExtractor(ctx context.Context) map[string]string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return map[string]string{
+		errs.TraceIDKey: sc.TraceID().String(),
+		errs.SpanIDKey:  sc.SpanID().String(),
+	}
+}
@@ -0,0 +1,122 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRecordError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedHTTP int64
+		expectedGRPC int64
+		expectedType string
+		expectedCode codes.Code
+	}{
+		{"HTTP Bad Request", errs.BadRequestHTTP("Bad request"), 400, 3, "INVALID_ARGUMENT", codes.Unset},
+		{"gRPC Not Found", errs.NotFoundGRPC("Not found"), 404, 5, "NOT_FOUND", codes.Unset},
+		{"HTTP Internal Server Error", errs.InternalServerHTTP("Internal"), 500, 13, "INTERNAL", codes.Error},
+		{"gRPC Unavailable", errs.UnavailableGRPC("Unavailable"), 503, 14, "UNAVAILABLE", codes.Error},
+		{"Context Deadline Exceeded", context.DeadlineExceeded, 504, 4, "context.deadlineExceededError", codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+			ctx, span := provider.Tracer("test").Start(context.Background(), "handler")
+			RecordError(ctx, tt.err)
+			span.End()
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("Expected 1 span, got %d", len(spans))
+			}
+
+			attrs := attribute.NewSet(spans[0].Attributes()...)
+			if v, _ := attrs.Value(HTTPStatusCodeKey); v.AsInt64() != tt.expectedHTTP {
+				t.Errorf("Expected %s %d, got %d", HTTPStatusCodeKey, tt.expectedHTTP, v.AsInt64())
+			}
+			if v, _ := attrs.Value(GRPCStatusCodeKey); v.AsInt64() != tt.expectedGRPC {
+				t.Errorf("Expected %s %d, got %d", GRPCStatusCodeKey, tt.expectedGRPC, v.AsInt64())
+			}
+			if v, _ := attrs.Value(ErrorTypeKey); v.AsString() != tt.expectedType {
+				t.Errorf("Expected %s '%s', got '%s'", ErrorTypeKey, tt.expectedType, v.AsString())
+			}
+			if spans[0].Status().Code != tt.expectedCode {
+				t.Errorf("Expected span status %v, got %v", tt.expectedCode, spans[0].Status().Code)
+			}
+			if len(spans[0].Events()) != 1 {
+				t.Errorf("Expected 1 exception event, got %d", len(spans[0].Events()))
+			}
+		})
+	}
+}
+
+func TestRecordErrorRedacted(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "handler")
+	RecordError(ctx, errs.InternalServerHTTP("Charge failed for bob@example.com"))
+	span.End()
+
+	ended := recorder.Ended()[0]
+	expected := "Charge failed for " + errs.RedactedPlaceholder
+	if ended.Status().Description != expected {
+		t.Errorf("Expected status description '%s', got '%s'", expected, ended.Status().Description)
+	}
+
+	events := ended.Events()
+	if len(events) != 1 || events[0].Name != ExceptionEventName {
+		t.Fatalf("Expected one exception event, got %v", events)
+	}
+	attrs := attribute.NewSet(events[0].Attributes...)
+	if v, _ := attrs.Value(ExceptionMessageKey); v.AsString() != expected {
+		t.Errorf("Expected exception message '%s', got '%s'", expected, v.AsString())
+	}
+	if v, _ := attrs.Value(ExceptionTypeKey); v.AsString() != "*errors.Error" {
+		t.Errorf("Expected exception type '*errors.Error', got '%s'", v.AsString())
+	}
+}
+
+func TestRecordNilError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "handler")
+	RecordError(ctx, nil)
+	span.End()
+
+	if len(recorder.Ended()[0].Events()) != 0 {
+		t.Error("Expected no events for nil error")
+	}
+}
+
+func TestContextExtractor(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "handler")
+	defer span.End()
+
+	errs.RegisterContextExtractor(ContextExtractor)
+	err := errs.WithContext(ctx, errs.NotFoundHTTP("User not found"))
+
+	metadata := errs.Metadata(err)
+	if metadata[errs.TraceIDKey] != span.SpanContext().TraceID().String() {
+		t.Errorf("Expected trace ID '%s', got '%s'", span.SpanContext().TraceID(), metadata[errs.TraceIDKey])
+	}
+	if metadata[errs.SpanIDKey] != span.SpanContext().SpanID().String() {
+		t.Errorf("Expected span ID '%s', got '%s'", span.SpanContext().SpanID(), metadata[errs.SpanIDKey])
+	}
+	if ContextExtractor(context.Background()) != nil {
+		t.Error("Expected no metadata without a span")
+	}
+}
@@ -0,0 +1,93 @@
+// Package problem отображает ошибки в формат application/problem+json (RFC 9457)
+// Package problem renders errors as application/problem+json (RFC 9457)
+package problem
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	errs "github.com/eserg-key/errors"
+	"github.com/eserg-key/errors/i18n"
+	"golang.org/x/text/language"
+)
+
+// ContentType - тип содержимого ответа
+// ContentType - content type of the response
+const ContentType = "application/problem+json"
+
+// DefaultType - тип проблемы, если он не задан (RFC 9457, раздел 4.2.1)
+// DefaultType - problem type used when none is set (RFC 9457, section 4.2.1)
+const DefaultType = "about:blank"
+
+// Problem - описание проблемы по RFC 9457. Поля code, reason и metadata - расширения
+// Problem - problem details per RFC 9457. The code, reason and metadata fields are extensions
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Code     string            `json:"code"`
+	Reason   string            `json:"reason,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// New преобразует ошибку в Problem. Описанием становится PublicMessage,
+// а instance - идентификатор экземпляра ошибки
+// New converts the error into a Problem. The detail is PublicMessage
+// and the instance is the instance ID of the error
+func New(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+
+	status := errs.StatusHTTP(err)
+	return &Problem{
+		Type:     DefaultType,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   errs.PublicMessage(err),
+		Instance: errs.InstanceID(err),
+		Code:     errs.CodeName(err),
+		Reason:   errs.Reason(err),
+		Metadata: errs.PublicMetadata(err),
+	}
+}
+
+// NewLocalized преобразует ошибку в Problem с описанием на указанном языке
+// NewLocalized converts the error into a Problem with the detail in the given language
+func NewLocalized(err error, localizer *i18n.Localizer, tag language.Tag) *Problem {
+	p := New(err)
+	if p != nil {
+		p.Detail = localizer.Localize(err, tag)
+	}
+	return p
+}
+
+// Write записывает ошибку в ответ как application/problem+json. Если localizer
+// не nil, описание локализуется на язык из заголовка Accept-Language запроса,
+// который возвращается в заголовке Content-Language
+// Write writes the error to the response as application/problem+json. If localizer
+// is not nil, the detail is localized into the language from the Accept-Language
+// request header, which is returned in the Content-Language header
+func Write(w http.ResponseWriter, r *http.Request, err error, localizer *i18n.Localizer) error {
+	p := New(err)
+	if p == nil {
+		return nil
+	}
+
+	if localizer != nil {
+		tag := localizer.Match(r.Header.Values("Accept-Language")...)
+		p = NewLocalized(err, localizer, tag)
+		w.Header().Set("Content-Language", tag.String())
+	}
+	if d, ok := errs.RetryAfter(err); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,103 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/eserg-key/errors"
+	"github.com/eserg-key/errors/i18n"
+	"golang.org/x/text/language"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Problem
+	}{
+		{
+			"HTTP Not Found",
+			errs.WithReason(errs.NotFoundHTTP("User not found"), "USER_NOT_FOUND"),
+			Problem{Type: DefaultType, Title: "Not Found", Status: 404, Detail: "User not found", Code: "NOT_FOUND", Reason: "USER_NOT_FOUND"},
+		},
+		{
+			"gRPC Unavailable",
+			errs.UnavailableGRPC("Backend unavailable"),
+			Problem{Type: DefaultType, Title: "Service Unavailable", Status: 503, Detail: "Backend unavailable", Code: "UNAVAILABLE"},
+		},
+		{
+			"Redacted Detail",
+			errs.BadRequestHTTP("Invalid email bob@example.com"),
+			Problem{Type: DefaultType, Title: "Bad Request", Status: 400, Detail: "Invalid email [REDACTED]", Code: "INVALID_ARGUMENT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(tt.err)
+			if p.Type != tt.expected.Type || p.Title != tt.expected.Title || p.Status != tt.expected.Status ||
+				p.Detail != tt.expected.Detail || p.Code != tt.expected.Code || p.Reason != tt.expected.Reason {
+				t.Errorf("Expected %+v, got %+v", tt.expected, *p)
+			}
+		})
+	}
+
+	if New(nil) != nil {
+		t.Error("Expected nil for nil error")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	catalog := i18n.NewCatalog()
+	catalog.Add("USER_NOT_FOUND", language.English, "User not found")
+	catalog.Add("USER_NOT_FOUND", language.Russian, "Пользователь не найден")
+	localizer := i18n.NewLocalizer(catalog, language.English, language.Russian)
+
+	err := errs.New(errs.ProtocolHTTP, 404, "user 7 missing",
+		errs.ReasonOption("USER_NOT_FOUND"),
+		errs.RetryAfterOption(1500*time.Millisecond),
+	)
+
+	r := httptest.NewRequest(http.MethodGet, "/users/7", nil)
+	r.Header.Set("Accept-Language", "ru-RU,ru;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+	if err := Write(w, r, err, localizer); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if w.Code != 404 {
+		t.Errorf("Expected status 404, got %d", w.Code)
+	}
+	if w.Header().Get("Content-Type") != ContentType {
+		t.Errorf("Expected content type '%s', got '%s'", ContentType, w.Header().Get("Content-Type"))
+	}
+	if w.Header().Get("Content-Language") != "ru" {
+		t.Errorf("Expected content language 'ru', got '%s'", w.Header().Get("Content-Language"))
+	}
+	if w.Header().Get("Retry-After") != "2" {
+		t.Errorf("Expected Retry-After '2', got '%s'", w.Header().Get("Retry-After"))
+	}
+
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	if p.Detail != "Пользователь не найден" {
+		t.Errorf("Expected localized detail, got '%s'", p.Detail)
+	}
+	if p.Instance == "" || p.Instance != errs.InstanceID(err) {
+		t.Errorf("Expected instance '%s', got '%s'", errs.InstanceID(err), p.Instance)
+	}
+
+	w = httptest.NewRecorder()
+	Write(w, httptest.NewRequest(http.MethodGet, "/users/7", nil), err, nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("Unexpected unmarshal error: %v", err)
+	}
+	if p.Detail != "user 7 missing" || w.Header().Get("Content-Language") != "" {
+		t.Errorf("Expected the original detail without a localizer, got '%s'", p.Detail)
+	}
+}
@@ -0,0 +1,175 @@
+package errors
+
+//go:generate protoc -I proto --go_out=. --go_opt=module=github.com/eserg-key/errors errors/v1/errors.proto
+
+import (
+	"github.com/eserg-key/errors/errorspb"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ToProto преобразует ошибку и цепочку её причин в protobuf-сообщение. Ошибки
+// других типов на верхнем уровне кодируются с gRPC-кодом, полученным через StatusGRPC.
+// Детали, которые не удается упаковать в google.protobuf.Any (proto.Marshal вернул
+// ошибку, например из-за некорректной UTF-8 строки), пропускаются; MarshalJSON в этом
+// случае возвращает ошибку
+// ToProto converts an error and its cause chain into a protobuf message. Top-level
+// errors of other types are encoded with the gRPC code obtained via StatusGRPC.
+// Details that cannot be packed into google.protobuf.Any (proto.Marshal failed, e.g.
+// on an invalid UTF-8 string) are skipped; MarshalJSON returns an error in that case.
+// Messages and metadata are redacted by the Redactor set via SetRedactor, as in MarshalJSON
+func ToProto(err error) *errorspb.Error {
+	return toProto(err, redactor.Load())
+}
+
+// ToTrustedProto преобразует ошибку в protobuf-сообщение без скрытия данных.
+// Используется только для доверенных получателей
+// ToTrustedProto converts the error into a protobuf message without redaction.
+// Use it for trusted sinks only
+func ToTrustedProto(err error) *errorspb.Error {
+	return toProto(err, nil)
+}
+
+// toProto converts the error into a protobuf message redacting it with r
+func toProto(err error, r *Redactor) *errorspb.Error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(*Error); !ok {
+		pb := &errorspb.Error{
+			Message:  r.String(err.Error()),
+			Protocol: errorspb.Protocol_PROTOCOL_GRPC,
+			Code:     uint32(StatusGRPC(err)),
+		}
+		setProtoCauses(pb, err, r)
+		return pb
+	}
+	return causeToProto(err, r)
+}
+
+// FromProto восстанавливает ошибку и цепочку её причин из protobuf-сообщения.
+// Коды, недопустимые для протокола, как и в UnmarshalJSON, не принимаются:
+// такие ошибки восстанавливаются с gRPC кодом Unknown
+// FromProto restores an error and its cause chain from a protobuf message.
+// Codes invalid for the protocol are not accepted, as in UnmarshalJSON:
+// such errors are restored with the gRPC code Unknown
+func FromProto(pb *errorspb.Error) error {
+	if pb == nil {
+		return nil
+	}
+	return causeFromProto(pb)
+}
+
+// causeToProto converts the error and its causes into protobuf messages.
+// Causes of other types keep only their message
+func causeToProto(err error, r *Redactor) *errorspb.Error {
+	if err == nil {
+		return nil
+	}
+
+	pb := &errorspb.Error{Message: r.String(err.Error())}
+	if er, ok := err.(*Error); ok {
+		pb.Message = r.String(er.Message)
+		pb.Protocol = protocolToProto(er.typeProtocol)
+		pb.Code = uint32(er.code)
+		pb.Reason = er.reason
+		pb.Domain = er.domain
+		pb.Metadata = r.Metadata(er.metadata)
+		pb.InstanceId = er.id
+		pb.Origin = er.origin
+
+		for _, detail := range er.details {
+			if packed, err := anypb.New(detail); err == nil {
+				pb.Details = append(pb.Details, packed)
+			}
+		}
+	}
+	setProtoCauses(pb, err, r)
+	return pb
+}
+
+// setProtoCauses converts the causes of the error into the cause or causes fields
+func setProtoCauses(pb *errorspb.Error, err error, r *Redactor) {
+	for _, cause := range unwrapAll(err) {
+		pb.Causes = append(pb.Causes, causeToProto(cause, r))
+	}
+	if len(pb.Causes) == 1 {
+		pb.Cause, pb.Causes = pb.Causes[0], nil
+	}
+}
+
+// fromProto converts the protobuf message into *Error. Invalid codes become Unknown
+func fromProto(pb *errorspb.Error) *Error {
+	protocol, code := protocolFromProto(pb.GetProtocol()), Code(pb.GetCode())
+	if !validCode(protocol, code) {
+		protocol, code = grpcProtocol, gRPCUnknown
+	}
+
+	er := &Error{
+		Message:      pb.GetMessage(),
+		code:         code,
+		typeProtocol: protocol,
+		reason:       pb.GetReason(),
+		domain:       pb.GetDomain(),
+		metadata:     pb.GetMetadata(),
+		id:           pb.GetInstanceId(),
+		origin:       pb.GetOrigin(),
+	}
+
+	// Details of types not linked into the binary are kept as google.protobuf.Any
+	for _, packed := range pb.GetDetails() {
+		detail, err := packed.UnmarshalNew()
+		if err != nil {
+			detail = packed
+		}
+		er.details = append(er.details, detail)
+	}
+
+	er.cause = causesFromProto(pb)
+	return er
+}
+
+// causeFromProto converts the protobuf message into an error.
+// Causes of other types carry only their message and are restored as plainError
+func causeFromProto(pb *errorspb.Error) error {
+	if pb.GetProtocol() == errorspb.Protocol_PROTOCOL_UNSPECIFIED && pb.GetCode() == 0 {
+		return &plainError{message: pb.GetMessage(), cause: causesFromProto(pb)}
+	}
+	return fromProto(pb)
+}
+
+// causesFromProto converts the cause or causes fields into the cause of the error
+func causesFromProto(pb *errorspb.Error) error {
+	var list []error
+	if pb.GetCause() != nil {
+		list = append(list, causeFromProto(pb.GetCause()))
+	}
+	for _, cause := range pb.GetCauses() {
+		list = append(list, causeFromProto(cause))
+	}
+	return joinCauses(list)
+}
+
+// protocolToProto converts ProtocolType into the protobuf enum
+func protocolToProto(protocol ProtocolType) errorspb.Protocol {
+	switch protocol {
+	case httpProtocol:
+		return errorspb.Protocol_PROTOCOL_HTTP
+	case grpcProtocol:
+		return errorspb.Protocol_PROTOCOL_GRPC
+	default:
+		return errorspb.Protocol_PROTOCOL_UNSPECIFIED
+	}
+}
+
+// protocolFromProto converts the protobuf enum into ProtocolType
+func protocolFromProto(protocol errorspb.Protocol) ProtocolType {
+	switch protocol {
+	case errorspb.Protocol_PROTOCOL_HTTP:
+		return httpProtocol
+	case errorspb.Protocol_PROTOCOL_GRPC:
+		return grpcProtocol
+	default:
+		return ""
+	}
+}
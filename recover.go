@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"runtime/debug"
+)
+
+// PanicError хранит значение перехваченной паники
+// PanicError holds the value of a recovered panic
+type PanicError struct {
+	Value any
+}
+
+// Error возвращает текстовое представление паники
+// Error returns a text representation of the panic
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", p.Value)
+}
+
+// Unwrap возвращает значение паники, если оно является ошибкой (например, runtime.Error)
+// Unwrap returns the panic value if it is an error (e.g. runtime.Error)
+func (p *PanicError) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// Recover перехватывает панику и записывает в errp ошибку с gRPC кодом 13,
+// значением паники и стеком вызовов. Должна вызываться через defer
+// Recover recovers a panic and stores into errp an error with gRPC code 13,
+// the panic value and the call stack. It must be called with defer
+func Recover(errp *error) {
+	if r := recover(); r != nil {
+		*errp = newPanicError(r)
+	}
+}
+
+// Go запускает f в отдельной горутине и возвращает канал с её результатом.
+// Паника в f преобразуется в ошибку так же, как в Recover
+// Go runs f in a separate goroutine and returns a channel with its result.
+// A panic in f is converted into an error the same way as in Recover
+func Go(f func() error) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- callRecover(f)
+	}()
+	return result
+}
+
+// PanicValue возвращает значение паники, сохранённое в ошибке
+// PanicValue returns the panic value stored in the error
+func PanicValue(err error) (any, bool) {
+	var pe *PanicError
+	if errors.As(err, &pe) {
+		return pe.Value, true
+	}
+	return nil, false
+}
+
+// callRecover вызывает f и преобразует панику в ошибку
+// callRecover calls f and converts a panic into an error
+func callRecover(f func() error) (err error) {
+	defer Recover(&err)
+	return f()
+}
+
+// newPanicError создает ошибку с gRPC кодом 13 из значения паники
+// newPanicError creates an error with gRPC code 13 from a panic value
+func newPanicError(r any) error {
+	cause := &PanicError{Value: r}
+	return (&Error{
+		Message:      cause.Error(),
+		code:         gRPCInternal,
+		typeProtocol: grpcProtocol,
+		stack:        debug.Stack(),
+		cause:        cause,
+	}).stamp()
+}
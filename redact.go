@@ -0,0 +1,163 @@
+package errors
+
+import (
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// RedactedPlaceholder заменяет скрытые данные
+// RedactedPlaceholder replaces redacted data
+const RedactedPlaceholder = "[REDACTED]"
+
+// RedactRule скрывает чувствительные данные в строке
+// RedactRule redacts sensitive data in a string
+type RedactRule func(s string) string
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer)\s+[A-Za-z0-9\-._~+/]+=*`)
+	panPattern    = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+)
+
+// RedactEmails скрывает адреса электронной почты
+// RedactEmails redacts email addresses
+func RedactEmails(s string) string {
+	return emailPattern.ReplaceAllString(s, RedactedPlaceholder)
+}
+
+// RedactBearerTokens скрывает bearer-токены, оставляя схему авторизации
+// RedactBearerTokens redacts bearer tokens, keeping the authorization scheme
+func RedactBearerTokens(s string) string {
+	return bearerPattern.ReplaceAllString(s, "$1 "+RedactedPlaceholder)
+}
+
+// RedactCardNumbers скрывает номера платежных карт, проходящие проверку Луна
+// RedactCardNumbers redacts payment card numbers passing the Luhn check
+func RedactCardNumbers(s string) string {
+	return panPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if luhn(match) {
+			return RedactedPlaceholder
+		}
+		return match
+	})
+}
+
+// RedactPattern возвращает правило, заменяющее совпадения с выражением на replacement
+// RedactPattern returns a rule replacing matches of the expression with replacement
+func RedactPattern(re *regexp.Regexp, replacement string) RedactRule {
+	return func(s string) string {
+		return re.ReplaceAllString(s, replacement)
+	}
+}
+
+// Redactor скрывает чувствительные данные в сообщениях и метаданных ошибок.
+// Значения метаданных с ключами из DenyKeys скрываются целиком, ключи сравниваются
+// без учета регистра. nil Redactor ничего не скрывает
+// Redactor redacts sensitive data in error messages and metadata.
+// Metadata values with keys from DenyKeys are redacted entirely, keys are compared
+// case-insensitively. A nil Redactor redacts nothing
+type Redactor struct {
+	Rules    []RedactRule
+	DenyKeys []string
+}
+
+// DefaultRedactor скрывает адреса электронной почты, bearer-токены, номера карт
+// и значения метаданных с типичными ключами секретов
+// DefaultRedactor redacts emails, bearer tokens, card numbers and metadata
+// values with typical secret keys
+var DefaultRedactor = &Redactor{
+	Rules:    []RedactRule{RedactEmails, RedactBearerTokens, RedactCardNumbers},
+	DenyKeys: []string{"password", "secret", "token", "authorization", "api_key", "cookie"},
+}
+
+// redactor holds the redactor applied when rendering errors
+var redactor atomic.Pointer[Redactor]
+
+func init() {
+	SetRedactor(DefaultRedactor)
+}
+
+// SetRedactor задает Redactor, применяемый при выводе ошибок клиентам (PublicMessage,
+// PublicMetadata), в логи (LogValue, ErrorInfo), в JSON (MarshalJSON) и в protobuf (ToProto).
+// nil отключает скрытие
+// SetRedactor sets the Redactor applied when rendering errors to clients (PublicMessage,
+// PublicMetadata), logs (LogValue, ErrorInfo), JSON (MarshalJSON) and protobuf (ToProto).
+// nil disables redaction
+func SetRedactor(r *Redactor) {
+	redactor.Store(r)
+}
+
+// String скрывает чувствительные данные в строке
+// String redacts sensitive data in the string
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, rule := range r.Rules {
+		s = rule(s)
+	}
+	return s
+}
+
+// Metadata возвращает копию метаданных со скрытыми значениями
+// Metadata returns a copy of the metadata with redacted values
+func (r *Redactor) Metadata(metadata map[string]string) map[string]string {
+	if r == nil || metadata == nil {
+		return metadata
+	}
+
+	redacted := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		if r.denied(k) {
+			redacted[k] = RedactedPlaceholder
+		} else {
+			redacted[k] = r.String(v)
+		}
+	}
+	return redacted
+}
+
+// denied reports whether the metadata key is in the denylist
+func (r *Redactor) denied(key string) bool {
+	for _, k := range r.DenyKeys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// PublicMetadata возвращает метаданные ошибки, безопасные для показа клиенту
+// PublicMetadata returns the metadata of the error that is safe to show to the client
+func PublicMetadata(err error) map[string]string {
+	return redactor.Load().Metadata(Metadata(err))
+}
+
+// MarshalTrustedJSON кодирует ошибку в JSON без скрытия данных. Используется
+// только для доверенных получателей
+// MarshalTrustedJSON encodes the error to JSON without redaction. Use it
+// for trusted sinks only
+func MarshalTrustedJSON(err *Error) ([]byte, error) {
+	return marshalJSON(err, nil)
+}
+
+// luhn reports whether the digits of the string pass the Luhn check
+func luhn(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			continue
+		}
+
+		d := int(s[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
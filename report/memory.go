@@ -0,0 +1,32 @@
+package report
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryReporter сохраняет события в памяти. Предназначен для тестов
+// MemoryReporter stores events in memory. It is intended for tests
+type MemoryReporter struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// Report реализует Reporter
+// Report implements Reporter
+func (r *MemoryReporter) Report(_ context.Context, event Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+	return nil
+}
+
+// Events возвращает копию сохранённых событий
+// Events returns a copy of the stored events
+func (r *MemoryReporter) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Event(nil), r.events...)
+}
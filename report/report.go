@@ -0,0 +1,189 @@
+// Package report отправляет ошибки в системы отслеживания исключений
+// Package report sends errors to exception trackers
+package report
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	errs "github.com/eserg-key/errors"
+)
+
+// DefaultQueueSize - размер очереди событий по умолчанию
+// DefaultQueueSize - default size of the event queue
+const DefaultQueueSize = 1024
+
+// Event - событие об ошибке, передаваемое Reporter
+// Event - error event passed to a Reporter
+type Event struct {
+	Err         error
+	Fingerprint string
+	Time        time.Time
+}
+
+// Reporter отправляет события в систему отслеживания исключений
+// Reporter sends events to an exception tracker
+type Reporter interface {
+	Report(ctx context.Context, event Event) error
+}
+
+// Config - настройки Dispatcher
+// Config - Dispatcher settings
+type Config struct {
+	// Filter отбирает ошибки для отправки. По умолчанию отправляются ошибки сервера (5xx)
+	// Filter selects errors to report. By default server errors (5xx) are reported
+	Filter func(err error) bool
+
+	// SampleRate - доля отправляемых событий от 0 до 1. Ноль означает 1
+	// SampleRate - fraction of events to report from 0 to 1. Zero means 1
+	SampleRate float64
+
+	// DedupWindow - интервал, в течение которого повторы с тем же отпечатком отбрасываются
+	// DedupWindow - interval during which repeats with the same fingerprint are dropped
+	DedupWindow time.Duration
+
+	// QueueSize - размер очереди. Ноль означает DefaultQueueSize
+	// QueueSize - size of the queue. Zero means DefaultQueueSize
+	QueueSize int
+
+	// OnError вызывается, если Reporter вернул ошибку
+	// OnError is called when a Reporter returns an error
+	OnError func(err error)
+}
+
+// Dispatcher фильтрует, дедуплицирует и сэмплирует ошибки и асинхронно
+// передает их всем Reporter через ограниченную очередь
+// Dispatcher filters, deduplicates and samples errors and asynchronously
+// hands them to every Reporter through a bounded queue
+type Dispatcher struct {
+	cfg       Config
+	reporters []Reporter
+	queue     chan Event
+	done      chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+	seen   map[string]time.Time
+	pruned time.Time
+	now    func() time.Time
+}
+
+// NewDispatcher создает Dispatcher и запускает его обработчик очереди
+// NewDispatcher creates a Dispatcher and starts its queue worker
+func NewDispatcher(cfg Config, reporters ...Reporter) *Dispatcher {
+	if cfg.Filter == nil {
+		cfg.Filter = errs.IsServerError
+	}
+	if cfg.SampleRate <= 0 {
+		cfg.SampleRate = 1
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultQueueSize
+	}
+
+	d := &Dispatcher{
+		cfg:       cfg,
+		reporters: reporters,
+		queue:     make(chan Event, cfg.QueueSize),
+		done:      make(chan struct{}),
+		seen:      make(map[string]time.Time),
+		now:       time.Now,
+	}
+	go d.run()
+	return d
+}
+
+// Dispatch ставит ошибку в очередь на отправку. Возвращает false, если ошибка
+// отфильтрована, отброшена как повтор или сэмплированием, очередь заполнена
+// или Dispatcher закрыт
+// Dispatch queues the error for reporting. It returns false if the error was
+// filtered out, dropped as a repeat or by sampling, the queue is full
+// or the Dispatcher is closed
+func (d *Dispatcher) Dispatch(err error) bool {
+	if err == nil || !d.cfg.Filter(err) {
+		return false
+	}
+
+	event := Event{Err: err, Fingerprint: Fingerprint(err), Time: d.now()}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed || d.repeated(event) {
+		return false
+	}
+	if d.cfg.SampleRate < 1 && rand.Float64() >= d.cfg.SampleRate {
+		return false
+	}
+
+	select {
+	case d.queue <- event:
+		if d.cfg.DedupWindow > 0 {
+			d.seen[event.Fingerprint] = event.Time
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// repeated reports whether an event with the same fingerprint was queued within
+// the dedup window. Entries older than the window are evicted once per window
+func (d *Dispatcher) repeated(event Event) bool {
+	if d.cfg.DedupWindow <= 0 {
+		return false
+	}
+
+	if event.Time.Sub(d.pruned) >= d.cfg.DedupWindow {
+		for fingerprint, last := range d.seen {
+			if event.Time.Sub(last) >= d.cfg.DedupWindow {
+				delete(d.seen, fingerprint)
+			}
+		}
+		d.pruned = event.Time
+	}
+
+	last, ok := d.seen[event.Fingerprint]
+	return ok && event.Time.Sub(last) < d.cfg.DedupWindow
+}
+
+// Close прекращает прием ошибок и ожидает отправки событий из очереди
+// или отмены контекста
+// Close stops accepting errors and waits until the queued events are sent
+// or the context is canceled
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run sends queued events to the reporters
+func (d *Dispatcher) run() {
+	defer close(d.done)
+
+	for event := range d.queue {
+		for _, reporter := range d.reporters {
+			if err := reporter.Report(context.Background(), event); err != nil && d.cfg.OnError != nil {
+				d.cfg.OnError(err)
+			}
+		}
+	}
+}
+
+// Fingerprint возвращает отпечаток ошибки для группировки, см. errors.Fingerprint
+// Fingerprint returns the fingerprint of the error used for grouping, see errors.Fingerprint
+func Fingerprint(err error) string {
+	return errs.Fingerprint(err)
+}
@@ -0,0 +1,142 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	errs "github.com/eserg-key/errors"
+)
+
+func TestDispatcher(t *testing.T) {
+	reporter := &MemoryReporter{}
+	d := NewDispatcher(Config{DedupWindow: time.Minute}, reporter)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Nil Error", nil, false},
+		{"Client Error", errs.BadRequestHTTP("Bad request"), false},
+		{"Server Error", errs.WithReason(errs.InternalServerHTTP("Internal"), "DB_DOWN"), true},
+		{"Duplicate Server Error", errs.WithReason(errs.InternalGRPC("Internal again"), "DB_DOWN"), false},
+		{"Other Server Error", errs.UnavailableGRPC("Unavailable"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := d.Dispatch(tt.err); got != tt.expected {
+				t.Errorf("Expected Dispatch to return %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if err := d.Close(context.Background()); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+	if len(reporter.Events()) != 2 {
+		t.Errorf("Expected 2 events, got %d", len(reporter.Events()))
+	}
+	if d.Dispatch(errs.InternalGRPC("After close")) {
+		t.Error("Expected Dispatch to fail after Close")
+	}
+}
+
+func TestDispatcherDedupWindow(t *testing.T) {
+	reporter := &MemoryReporter{}
+	d := NewDispatcher(Config{DedupWindow: time.Minute}, reporter)
+
+	now := time.Now()
+	d.now = func() time.Time { return now }
+	d.Dispatch(errs.InternalGRPC("First"))
+
+	now = now.Add(2 * time.Minute)
+	if !d.Dispatch(errs.InternalGRPC("Second")) {
+		t.Error("Expected repeat after the window to be dispatched")
+	}
+
+	d.Close(context.Background())
+	if len(reporter.Events()) != 2 {
+		t.Errorf("Expected 2 events, got %d", len(reporter.Events()))
+	}
+}
+
+func TestDispatcherPlainErrors(t *testing.T) {
+	reporter := &MemoryReporter{}
+	d := NewDispatcher(Config{DedupWindow: time.Minute}, reporter)
+
+	if !d.Dispatch(fmt.Errorf("db down")) || !d.Dispatch(fmt.Errorf("nil deref")) {
+		t.Error("Expected unrelated plain errors to be dispatched")
+	}
+	if d.Dispatch(fmt.Errorf("db down")) {
+		t.Error("Expected a repeated plain error to be dropped")
+	}
+
+	d.Close(context.Background())
+	if len(reporter.Events()) != 2 {
+		t.Errorf("Expected 2 events, got %d", len(reporter.Events()))
+	}
+}
+
+func TestDispatcherPrunesSeen(t *testing.T) {
+	d := NewDispatcher(Config{DedupWindow: time.Minute}, &MemoryReporter{})
+	defer d.Close(context.Background())
+
+	now := time.Now()
+	d.now = func() time.Time { return now }
+	d.Dispatch(errs.WithReason(errs.InternalGRPC("Internal"), "FIRST"))
+	d.Dispatch(errs.WithReason(errs.InternalGRPC("Internal"), "SECOND"))
+
+	now = now.Add(2 * time.Minute)
+	d.Dispatch(errs.WithReason(errs.InternalGRPC("Internal"), "THIRD"))
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.seen) != 1 {
+		t.Errorf("Expected expired fingerprints to be evicted, got %d entries", len(d.seen))
+	}
+}
+
+func TestDispatcherWithoutDedup(t *testing.T) {
+	reporter := &MemoryReporter{}
+	d := NewDispatcher(Config{}, reporter)
+
+	for i := 0; i < 3; i++ {
+		if !d.Dispatch(errs.InternalGRPC("Internal")) {
+			t.Error("Expected repeats to be dispatched without a dedup window")
+		}
+	}
+	d.Close(context.Background())
+
+	if len(d.seen) != 0 {
+		t.Errorf("Expected no fingerprints to be kept, got %d", len(d.seen))
+	}
+	if len(reporter.Events()) != 3 {
+		t.Errorf("Expected 3 events, got %d", len(reporter.Events()))
+	}
+}
+
+func TestDispatcherFilter(t *testing.T) {
+	reporter := &MemoryReporter{}
+	d := NewDispatcher(Config{Filter: func(error) bool { return true }}, reporter)
+
+	if !d.Dispatch(errs.NotFoundHTTP("Not found")) {
+		t.Error("Expected custom filter to accept client errors")
+	}
+	d.Close(context.Background())
+}
+
+func TestFingerprint(t *testing.T) {
+	a := errs.WithReason(errs.NotFoundHTTP("User 1 not found"), "USER_NOT_FOUND")
+	b := errs.WithReason(errs.NotFoundGRPC("User 2 not found"), "USER_NOT_FOUND")
+	c := errs.WithReason(errs.NotFoundHTTP("User 1 not found"), "ORDER_NOT_FOUND")
+
+	if Fingerprint(a) != Fingerprint(b) {
+		t.Error("Expected errors with the same reason and code to share a fingerprint")
+	}
+	if Fingerprint(a) == Fingerprint(c) {
+		t.Error("Expected errors with different reasons to have different fingerprints")
+	}
+}
@@ -0,0 +1,54 @@
+package errors
+
+// Ошибки-эталоны классов статусов. errors.Is(err, ErrNotFound) истинно для любой
+// ошибки, чей gRPC код (с учетом преобразования HTTP-статусов) равен NotFound,
+// например NotFoundHTTP, NotFoundGRPC или GoneHTTP
+// Sentinel errors of status classes. errors.Is(err, ErrNotFound) is true for any
+// error whose gRPC code (with HTTP statuses converted) is NotFound,
+// e.g. NotFoundHTTP, NotFoundGRPC or GoneHTTP
+var (
+	ErrCanceled           = newSentinel("canceled", gRPCCanceled)
+	ErrUnknown            = newSentinel("unknown", gRPCUnknown)
+	ErrInvalidArgument    = newSentinel("invalid argument", gRPCInvalidArgument)
+	ErrDeadlineExceeded   = newSentinel("deadline exceeded", gRPCDeadlineExceeded)
+	ErrNotFound           = newSentinel("not found", gRPCNotFound)
+	ErrAlreadyExists      = newSentinel("already exists", gRPCAlreadyExists)
+	ErrPermissionDenied   = newSentinel("permission denied", gRPCPermissionDenied)
+	ErrResourceExhausted  = newSentinel("resource exhausted", gRPCResourceExhausted)
+	ErrFailedPrecondition = newSentinel("failed precondition", gRPCFailedPrecondition)
+	ErrAborted            = newSentinel("aborted", gRPCAborted)
+	ErrOutOfRange         = newSentinel("out of range", gRPCOutOfRange)
+	ErrUnimplemented      = newSentinel("unimplemented", gRPCUnimplemented)
+	ErrInternal           = newSentinel("internal", gRPCInternal)
+	ErrUnavailable        = newSentinel("unavailable", gRPCUnavailable)
+	ErrDataLoss           = newSentinel("data loss", gRPCDataLoss)
+	ErrUnauthenticated    = newSentinel("unauthenticated", gRPCUnauthenticated)
+)
+
+// sentinels contains all sentinel errors
+var sentinels = map[*Error]struct{}{}
+
+// newSentinel creates a sentinel error with the gRPC code
+func newSentinel(message string, code Code) *Error {
+	er := &Error{Message: message, code: code, typeProtocol: grpcProtocol}
+	sentinels[er] = struct{}{}
+	return er
+}
+
+// Is сообщает, относится ли ошибка к классу статусов ошибки-эталона.
+// Остальные ошибки сравниваются errors.Is по равенству
+// Is reports whether the error belongs to the status class of a sentinel error.
+// Other errors are compared by errors.Is for equality
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if _, ok := sentinels[t]; !ok {
+		return false
+	}
+	if e.typeProtocol == grpcProtocol {
+		return e.code == t.code
+	}
+	return statusHTTPToGRPC(e.code) == t.code
+}
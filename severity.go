@@ -0,0 +1,83 @@
+package errors
+
+import "github.com/pkg/errors"
+
+// Severity - уровень серьезности ошибки для логирования и алертинга
+// Severity - severity level of the error for logging and alerting
+type Severity int
+
+// Severity levels
+const (
+	severityUnset Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+// String возвращает название уровня серьезности
+// String returns the name of the severity level
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "INFO"
+	case SeverityWarning:
+		return "WARN"
+	case SeverityError:
+		return "ERROR"
+	default:
+		return "UNSET"
+	}
+}
+
+// SeverityOf возвращает уровень серьезности ошибки: заданный через WithSeverity
+// или вычисленный по коду (ошибки клиента - WARN, ошибки сервера - ERROR)
+// SeverityOf returns the severity of the error: the one set via WithSeverity
+// or the one derived from the code (client errors - WARN, server errors - ERROR)
+func SeverityOf(err error) Severity {
+	if err == nil {
+		return SeverityInfo
+	}
+
+	var er *Error
+	if errors.As(err, &er) && er.severity != severityUnset {
+		return er.severity
+	}
+
+	switch {
+	case IsServerError(err):
+		return SeverityError
+	case IsClientError(err):
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// WithSeverity возвращает копию ошибки с явно заданным уровнем серьезности.
+// Ошибки других типов, в том числе обертки над *Error, становятся причиной новой ошибки
+// с их текстом, протоколом и кодом, как в Wrap
+// WithSeverity returns a copy of the error with an explicit severity.
+// Errors of other types, including wrappers of *Error, become the cause of a new error
+// with their text, protocol and code, as in Wrap
+func WithSeverity(err error, severity Severity) error {
+	if err == nil {
+		return nil
+	}
+
+	cp := annotate(err)
+	cp.severity = severity
+	return cp
+}
+
+// IsClientError сообщает, является ли ошибка ошибкой клиента (HTTP 4xx)
+// IsClientError reports whether the error is a client error (HTTP 4xx)
+func IsClientError(err error) bool {
+	status := StatusHTTP(err)
+	return status >= 400 && status < 500
+}
+
+// IsServerError сообщает, является ли ошибка ошибкой сервера (HTTP 5xx)
+// IsServerError reports whether the error is a server error (HTTP 5xx)
+func IsServerError(err error) bool {
+	return StatusHTTP(err) >= 500
+}
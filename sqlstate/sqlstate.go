@@ -0,0 +1,76 @@
+// Package sqlstate классифицирует ошибки драйверов БД по коду SQLSTATE
+// Package sqlstate classifies database driver errors by their SQLSTATE code
+package sqlstate
+
+import (
+	"errors"
+	"strings"
+
+	errs "github.com/eserg-key/errors"
+)
+
+// SQLStater реализуется любой ошибкой драйвера, которая возвращает код SQLSTATE
+// SQLStater is implemented by any driver error that exposes its SQLSTATE code
+type SQLStater interface {
+	SQLState() string
+}
+
+// SQLSTATE codes and classes handled by the package
+const (
+	UniqueViolation      = "23505"
+	ForeignKeyViolation  = "23503"
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
+	QueryCanceled        = "57014"
+
+	classInsufficientResources = "53"
+	classConnectionException   = "08"
+)
+
+// State возвращает код SQLSTATE из цепочки ошибок
+// State returns the SQLSTATE code found in the error chain
+func State(err error) (string, bool) {
+	var st SQLStater
+	if errors.As(err, &st) {
+		return st.SQLState(), true
+	}
+	return "", false
+}
+
+// Classify преобразует ошибку драйвера в *errors.Error по её коду SQLSTATE, сохраняя
+// её как причину. Ошибки без кода или с неизвестным кодом возвращаются без изменений
+// Classify converts a driver error into an *errors.Error by its SQLSTATE code, keeping
+// it as the cause. Errors without a code or with an unknown code are returned unchanged
+func Classify(err error) error {
+	state, ok := State(err)
+	if !ok {
+		return err
+	}
+
+	switch {
+	case state == UniqueViolation:
+		return errs.AlreadyExistsGRPCf("%w", err)
+	case state == ForeignKeyViolation:
+		return errs.FailedPreconditionGRPCf("%w", err)
+	case state == SerializationFailure, state == DeadlockDetected:
+		return errs.AbortedGRPCf("%w", err)
+	case state == QueryCanceled:
+		return errs.CanceledGRPCf("%w", err)
+	case strings.HasPrefix(state, classInsufficientResources):
+		return errs.ResourceExhaustedGRPCf("%w", err)
+	case strings.HasPrefix(state, classConnectionException):
+		return errs.UnavailableGRPCf("%w", err)
+	default:
+		return err
+	}
+}
+
+// IsRetryable сообщает, можно ли повторить транзакцию, завершившуюся ошибкой
+// IsRetryable reports whether the transaction that failed with err can be retried
+func IsRetryable(err error) bool {
+	state, ok := State(err)
+	if !ok {
+		return false
+	}
+	return state == SerializationFailure || state == DeadlockDetected
+}
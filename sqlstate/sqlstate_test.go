@@ -0,0 +1,80 @@
+package sqlstate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+)
+
+type driverError struct {
+	code string
+}
+
+func (e *driverError) Error() string    { return "driver: " + e.code }
+func (e *driverError) SQLState() string { return e.code }
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name         string
+		state        string
+		expectedHTTP int
+		expectedGRPC errs.Code
+	}{
+		{"Unique Violation", UniqueViolation, 409, 6},
+		{"Foreign Key Violation", ForeignKeyViolation, 400, 9},
+		{"Serialization Failure", SerializationFailure, 409, 10},
+		{"Query Canceled", QueryCanceled, 408, 1},
+		{"Too Many Connections", "53300", 429, 8},
+		{"Connection Failure", "08006", 503, 14},
+		{"Unknown State", "42601", 500, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cause := &driverError{code: tt.state}
+			err := Classify(fmt.Errorf("query: %w", cause))
+
+			if !errors.Is(err, cause) {
+				t.Error("Expected the driver error to stay in the chain")
+			}
+			if status := errs.StatusHTTP(err); status != tt.expectedHTTP {
+				t.Errorf("Expected HTTP status %d, got %d", tt.expectedHTTP, status)
+			}
+			if code := errs.StatusGRPC(err); code != tt.expectedGRPC {
+				t.Errorf("Expected gRPC code %d, got %d", tt.expectedGRPC, code)
+			}
+		})
+	}
+}
+
+func TestClassifyOrigin(t *testing.T) {
+	err := Classify(&driverError{code: UniqueViolation})
+	if origin := errs.ToProto(err).GetOrigin(); origin != "github.com/eserg-key/errors/sqlstate.TestClassifyOrigin" {
+		t.Errorf("Expected the caller of Classify as the origin, got %q", origin)
+	}
+}
+
+func TestClassifyWithoutState(t *testing.T) {
+	if Classify(nil) != nil {
+		t.Error("Expected nil for nil error")
+	}
+
+	err := fmt.Errorf("plain error")
+	if Classify(err) != err {
+		t.Error("Expected error without SQLSTATE to be returned unchanged")
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if !IsRetryable(&driverError{code: SerializationFailure}) {
+		t.Error("Expected serialization failure to be retryable")
+	}
+	if !IsRetryable(&driverError{code: DeadlockDetected}) {
+		t.Error("Expected deadlock to be retryable")
+	}
+	if IsRetryable(&driverError{code: UniqueViolation}) {
+		t.Error("Expected unique violation not to be retryable")
+	}
+}
@@ -0,0 +1,119 @@
+// Package twirp кодирует ошибки в JSON-формат ошибок протокола Twirp и обратно
+// Package twirp encodes errors into the Twirp protocol JSON error format and back
+package twirp
+
+import (
+	errs "github.com/eserg-key/errors"
+)
+
+// ReasonKey - ключ meta с причиной ошибки
+// ReasonKey - meta key with the error reason
+const ReasonKey = "reason"
+
+// codes lists Twirp codes and their HTTP statuses indexed by gRPC code
+var codes = [...]struct {
+	name   string
+	status int
+}{
+	errs.GRPCOk:                 {"", 200},
+	errs.GRPCCanceled:           {"canceled", 408},
+	errs.GRPCUnknown:            {"unknown", 500},
+	errs.GRPCInvalidArgument:    {"invalid_argument", 400},
+	errs.GRPCDeadlineExceeded:   {"deadline_exceeded", 408},
+	errs.GRPCNotFound:           {"not_found", 404},
+	errs.GRPCAlreadyExists:      {"already_exists", 409},
+	errs.GRPCPermissionDenied:   {"permission_denied", 403},
+	errs.GRPCResourceExhausted:  {"resource_exhausted", 429},
+	errs.GRPCFailedPrecondition: {"failed_precondition", 412},
+	errs.GRPCAborted:            {"aborted", 409},
+	errs.GRPCOutOfRange:         {"out_of_range", 400},
+	errs.GRPCUnimplemented:      {"unimplemented", 501},
+	errs.GRPCInternal:           {"internal", 500},
+	errs.GRPCUnavailable:        {"unavailable", 503},
+	errs.GRPCDataLoss:           {"dataloss", 500},
+	errs.GRPCUnauthenticated:    {"unauthenticated", 401},
+}
+
+// Twirp codes without a gRPC counterpart
+const (
+	malformed = "malformed"
+	badRoute  = "bad_route"
+)
+
+// Error - ошибка в JSON-формате Twirp
+// Error - error in the Twirp JSON format
+type Error struct {
+	Code string            `json:"code"`
+	Msg  string            `json:"msg"`
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// Encode преобразует ошибку в ошибку Twirp и возвращает HTTP-статус ответа
+// по спецификации Twirp. Метаданные и причина передаются в meta
+// Encode converts the error into a Twirp error and returns the HTTP status
+// of the response as specified by Twirp. Metadata and reason are sent in meta
+func Encode(err error) (*Error, int) {
+	if err == nil {
+		return nil, 200
+	}
+
+	code := errs.StatusGRPC(err)
+	if int(code) >= len(codes) || code == errs.GRPCOk {
+		code = errs.GRPCUnknown
+	}
+
+	e := &Error{Code: codes[code].name, Msg: errs.PublicMessage(err)}
+
+	metadata, reason := errs.PublicMetadata(err), errs.Reason(err)
+	if len(metadata) > 0 || reason != "" {
+		e.Meta = make(map[string]string, len(metadata)+1)
+		for k, v := range metadata {
+			e.Meta[k] = v
+		}
+		if reason != "" {
+			e.Meta[ReasonKey] = reason
+		}
+	}
+	return e, codes[code].status
+}
+
+// Decode преобразует ошибку Twirp в ошибку с gRPC кодом
+// Decode converts a Twirp error into an error with a gRPC code
+func Decode(e *Error) error {
+	if e == nil {
+		return nil
+	}
+
+	var opts []errs.Option
+	if len(e.Meta) > 0 {
+		metadata := make(map[string]string, len(e.Meta))
+		for k, v := range e.Meta {
+			if k == ReasonKey {
+				opts = append(opts, errs.ReasonOption(v))
+				continue
+			}
+			metadata[k] = v
+		}
+		if len(metadata) > 0 {
+			opts = append(opts, errs.MetadataOption(metadata))
+		}
+	}
+	return errs.New(errs.ProtocolGRPC, codeFromName(e.Code), e.Msg, opts...)
+}
+
+// codeFromName returns the gRPC code of the Twirp code name
+func codeFromName(name string) errs.Code {
+	switch name {
+	case malformed:
+		return errs.GRPCInvalidArgument
+	case badRoute:
+		return errs.GRPCUnimplemented
+	}
+
+	for code, c := range codes {
+		if code > int(errs.GRPCOk) && c.name == name {
+			return errs.Code(code)
+		}
+	}
+	return errs.GRPCUnknown
+}
@@ -0,0 +1,70 @@
+package twirp
+
+import (
+	"encoding/json"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedCode   string
+		expectedStatus int
+	}{
+		{"Not Found", errs.NotFoundHTTP("Not found"), "not_found", 404},
+		{"Deadline Exceeded", errs.DeadlineExceededGRPC("Timeout"), "deadline_exceeded", 408},
+		{"Failed Precondition", errs.FailedPreconditionGRPC("Precondition"), "failed_precondition", 412},
+		{"Data Loss", errs.DataLossGRPC("Lost"), "dataloss", 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, status := Encode(tt.err)
+			if e.Code != tt.expectedCode || status != tt.expectedStatus {
+				t.Errorf("Expected %s/%d, got %s/%d", tt.expectedCode, tt.expectedStatus, e.Code, status)
+			}
+		})
+	}
+
+	if e, status := Encode(nil); e != nil || status != 200 {
+		t.Error("Expected nil and 200 for nil error")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := errs.New(errs.ProtocolHTTP, 403, "Denied",
+		errs.ReasonOption("ROLE_REQUIRED"),
+		errs.MetadataOption(map[string]string{"role": "admin"}),
+	)
+
+	e, _ := Encode(original)
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Unexpected marshal error: %v", err)
+	}
+
+	expected := `{"code":"permission_denied","msg":"Denied","meta":{"reason":"ROLE_REQUIRED","role":"admin"}}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON '%s', got '%s'", expected, data)
+	}
+
+	decoded := Decode(e)
+	if errs.StatusHTTP(decoded) != 403 || errs.Reason(decoded) != "ROLE_REQUIRED" || errs.Metadata(decoded)["role"] != "admin" {
+		t.Errorf("Unexpected decoded error %v", decoded)
+	}
+	if _, ok := errs.Metadata(decoded)[ReasonKey]; ok {
+		t.Error("Expected reason to be removed from metadata")
+	}
+}
+
+func TestDecodeTwirpOnlyCodes(t *testing.T) {
+	if errs.StatusGRPC(Decode(&Error{Code: "malformed"})) != 3 {
+		t.Error("Expected malformed to decode as InvalidArgument")
+	}
+	if errs.StatusGRPC(Decode(&Error{Code: "bad_route"})) != 12 {
+		t.Error("Expected bad_route to decode as Unimplemented")
+	}
+}
@@ -0,0 +1,195 @@
+// Package websocket преобразует ошибки в коды закрытия WebSocket (RFC 6455) и обратно
+// без зависимости от конкретной библиотеки WebSocket
+// Package websocket converts errors into WebSocket close codes (RFC 6455) and back
+// without depending on a specific WebSocket library
+package websocket
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"unicode/utf8"
+
+	errs "github.com/eserg-key/errors"
+)
+
+// Close codes defined by RFC 6455
+const (
+	CloseNormalClosure    = 1000
+	CloseGoingAway        = 1001
+	CloseProtocolError    = 1002
+	CloseUnsupportedData  = 1003
+	CloseNoStatusReceived = 1005
+	CloseInvalidPayload   = 1007
+	ClosePolicyViolation  = 1008
+	CloseMessageTooBig    = 1009
+	CloseInternalError    = 1011
+	CloseTryAgainLater    = 1013
+
+	// Диапазон кодов приложения для зарегистрированных причин
+	// Application code range for registered reasons
+	CloseApplicationMin = 4000
+	CloseApplicationMax = 4999
+)
+
+// maxReasonLength - максимальная длина текста причины в кадре закрытия
+// maxReasonLength - maximum length of the reason text in a close frame
+const maxReasonLength = 123
+
+// Registry сопоставляет причины ошибок кодам закрытия приложения 4000-4999.
+// Нулевое значение и nil готовы к использованию и не содержат причин
+// Registry maps error reasons to application close codes 4000-4999.
+// A zero value and nil are ready to use and have no reasons registered
+type Registry struct {
+	mu       sync.RWMutex
+	byReason map[string]int
+	byCode   map[int]registration
+}
+
+// registration is a registered reason with its gRPC code
+type registration struct {
+	reason string
+	code   errs.Code
+}
+
+// Register связывает причину ошибки с кодом закрытия приложения и gRPC кодом,
+// который получит ошибка при разборе кадра закрытия. Код должен быть gRPC кодом ошибки 1-16
+// Register binds an error reason to an application close code and to the gRPC code
+// the error gets when the close frame is parsed. The code must be a gRPC error code 1-16
+func (r *Registry) Register(closeCode int, reason string, code errs.Code) error {
+	if closeCode < CloseApplicationMin || closeCode > CloseApplicationMax {
+		return fmt.Errorf("websocket: close code %d is outside of the application range", closeCode)
+	}
+	if code < errs.GRPCCanceled || code > errs.GRPCUnauthenticated {
+		return fmt.Errorf("%w: %d for %q", errs.ErrInvalidCode, code, errs.ProtocolGRPC)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byCode[closeCode]; ok {
+		return fmt.Errorf("websocket: close code %d is already registered", closeCode)
+	}
+	if _, ok := r.byReason[reason]; ok {
+		return fmt.Errorf("websocket: reason %q is already registered", reason)
+	}
+	if r.byCode == nil {
+		r.byCode = make(map[int]registration)
+		r.byReason = make(map[string]int)
+	}
+
+	r.byCode[closeCode] = registration{reason: reason, code: code}
+	r.byReason[reason] = closeCode
+	return nil
+}
+
+// CloseCode возвращает код и текст закрытия для ошибки. Для nil возвращается 1000
+// CloseCode returns the close code and text for the error. For nil it returns 1000
+func (r *Registry) CloseCode(err error) (int, string) {
+	if err == nil {
+		return CloseNormalClosure, ""
+	}
+
+	text := truncate(errs.PublicMessage(err))
+	if code, ok := r.lookupReason(errs.Reason(err)); ok {
+		return code, text
+	}
+
+	switch errs.StatusGRPC(err) {
+	case errs.GRPCPermissionDenied, errs.GRPCUnauthenticated, errs.GRPCInvalidArgument, errs.GRPCFailedPrecondition:
+		return ClosePolicyViolation, text
+	case errs.GRPCUnavailable, errs.GRPCResourceExhausted, errs.GRPCAborted:
+		return CloseTryAgainLater, text
+	default:
+		return CloseInternalError, text
+	}
+}
+
+// CloseFrame возвращает полезную нагрузку кадра закрытия для ошибки
+// CloseFrame returns the close frame payload for the error
+func (r *Registry) CloseFrame(err error) []byte {
+	code, text := r.CloseCode(err)
+
+	payload := make([]byte, 2, 2+len(text))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	return append(payload, text...)
+}
+
+// FromCloseCode преобразует код и текст закрытия в ошибку. Для 1000 возвращается nil
+// FromCloseCode converts a close code and text into an error. For 1000 it returns nil
+func (r *Registry) FromCloseCode(closeCode int, text string) error {
+	if reg, ok := r.lookupCode(closeCode); ok {
+		return errs.New(errs.ProtocolGRPC, reg.code, text, errs.ReasonOption(reg.reason))
+	}
+
+	var code errs.Code
+	switch closeCode {
+	case CloseNormalClosure, CloseNoStatusReceived:
+		return nil
+	case CloseGoingAway, CloseTryAgainLater:
+		code = errs.GRPCUnavailable
+	case ClosePolicyViolation:
+		code = errs.GRPCPermissionDenied
+	case CloseProtocolError, CloseUnsupportedData, CloseInvalidPayload:
+		code = errs.GRPCInvalidArgument
+	case CloseMessageTooBig:
+		code = errs.GRPCResourceExhausted
+	case CloseInternalError:
+		code = errs.GRPCInternal
+	default:
+		code = errs.GRPCUnknown
+	}
+	return errs.New(errs.ProtocolGRPC, code, text)
+}
+
+// ParseCloseFrame преобразует полезную нагрузку кадра закрытия в ошибку
+// ParseCloseFrame converts a close frame payload into an error
+func (r *Registry) ParseCloseFrame(payload []byte) error {
+	switch {
+	case len(payload) == 0:
+		return r.FromCloseCode(CloseNoStatusReceived, "")
+	case len(payload) == 1 || !utf8.Valid(payload[2:]):
+		return r.FromCloseCode(CloseProtocolError, "websocket: invalid close frame")
+	default:
+		return r.FromCloseCode(int(binary.BigEndian.Uint16(payload)), string(payload[2:]))
+	}
+}
+
+// lookupReason returns the close code registered for the reason
+func (r *Registry) lookupReason(reason string) (int, bool) {
+	if r == nil || reason == "" {
+		return 0, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	code, ok := r.byReason[reason]
+	return code, ok
+}
+
+// lookupCode returns the registration of the close code
+func (r *Registry) lookupCode(closeCode int) (registration, bool) {
+	if r == nil {
+		return registration{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reg, ok := r.byCode[closeCode]
+	return reg, ok
+}
+
+// truncate shortens the text to maxReasonLength bytes without splitting UTF-8 characters
+func truncate(text string) string {
+	if len(text) <= maxReasonLength {
+		return text
+	}
+
+	cut := maxReasonLength
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
@@ -0,0 +1,104 @@
+package websocket
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	errs "github.com/eserg-key/errors"
+)
+
+func TestCloseCode(t *testing.T) {
+	registry := &Registry{}
+	if err := registry.Register(4001, "SESSION_EXPIRED", errs.GRPCUnauthenticated); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"Nil Error", nil, CloseNormalClosure},
+		{"Permission Denied", errs.ForbiddenHTTP("Denied"), ClosePolicyViolation},
+		{"Unavailable", errs.UnavailableGRPC("Restarting"), CloseTryAgainLater},
+		{"Internal", errs.InternalGRPC("Internal"), CloseInternalError},
+		{"Registered Reason", errs.WithReason(errs.UnauthenticatedGRPC("Expired"), "SESSION_EXPIRED"), 4001},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _ := registry.CloseCode(tt.err); code != tt.expected {
+				t.Errorf("Expected close code %d, got %d", tt.expected, code)
+			}
+		})
+	}
+}
+
+func TestCloseFrameRoundTrip(t *testing.T) {
+	registry := &Registry{}
+	if err := registry.Register(4001, "SESSION_EXPIRED", errs.GRPCUnauthenticated); err != nil {
+		t.Fatal(err)
+	}
+
+	original := errs.WithReason(errs.UnauthenticatedGRPC("Session expired"), "SESSION_EXPIRED")
+	decoded := registry.ParseCloseFrame(registry.CloseFrame(original))
+
+	if decoded.Error() != "Session expired" || errs.Reason(decoded) != "SESSION_EXPIRED" || errs.StatusHTTP(decoded) != 401 {
+		t.Errorf("Unexpected decoded error %v", decoded)
+	}
+
+	decoded = registry.ParseCloseFrame(registry.CloseFrame(errs.UnavailableGRPC("Restarting")))
+	if errs.StatusGRPC(decoded) != 14 {
+		t.Errorf("Expected gRPC code 14, got %d", errs.StatusGRPC(decoded))
+	}
+
+	if registry.ParseCloseFrame(nil) != nil || registry.ParseCloseFrame(registry.CloseFrame(nil)) != nil {
+		t.Error("Expected nil for normal closure")
+	}
+	if errs.StatusGRPC(registry.ParseCloseFrame([]byte{0x03})) != 3 {
+		t.Error("Expected invalid frame to be an invalid argument error")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	var registry Registry
+
+	if registry.Register(1008, "REASON", 7) == nil {
+		t.Error("Expected an error for a code outside of the application range")
+	}
+	if err := registry.Register(4000, "REASON", 7); err != nil {
+		t.Fatal(err)
+	}
+	if registry.Register(4000, "OTHER", 7) == nil {
+		t.Error("Expected an error for a duplicate code")
+	}
+	if registry.Register(4002, "REASON", 7) == nil {
+		t.Error("Expected an error for a duplicate reason")
+	}
+	for _, code := range []errs.Code{errs.GRPCOk, 17, 404} {
+		if err := registry.Register(4003, "INVALID", code); !errors.Is(err, errs.ErrInvalidCode) {
+			t.Errorf("Expected ErrInvalidCode for gRPC code %d, got %v", code, err)
+		}
+	}
+}
+
+func TestNilRegistry(t *testing.T) {
+	var registry *Registry
+
+	if code, _ := registry.CloseCode(errs.ForbiddenHTTP("Denied")); code != ClosePolicyViolation {
+		t.Errorf("Expected close code %d, got %d", ClosePolicyViolation, code)
+	}
+	if errs.StatusGRPC(registry.FromCloseCode(4001, "Unknown")) != 2 {
+		t.Error("Expected unregistered application code to be unknown")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	text := strings.Repeat("ж", 100)
+	_, got := (&Registry{}).CloseCode(errs.InternalGRPC(text))
+
+	if len(got) > maxReasonLength || !strings.HasPrefix(text, got) {
+		t.Errorf("Expected truncated UTF-8 text of at most %d bytes, got %d", maxReasonLength, len(got))
+	}
+}